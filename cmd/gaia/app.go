@@ -0,0 +1,289 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/abci/server"
+	abci "github.com/tendermint/abci/types"
+	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
+	"github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/proxy"
+	"github.com/tendermint/tendermint/types"
+	"github.com/tendermint/tmlibs/cli"
+	tmflags "github.com/tendermint/tmlibs/cli/flags"
+	"github.com/tendermint/tmlibs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/app"
+	sdkerrors "github.com/cosmos/cosmos-sdk/errors"
+	"github.com/cosmos/cosmos-sdk/genesis"
+	basecmd "github.com/cosmos/cosmos-sdk/server/commands"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/gov"
+	"github.com/cosmos/gaia/modules/stake"
+	"github.com/cosmos/gaia/version"
+)
+
+// gaiaApp - the Basecoin app with the hooks of gaia into the ABCI calls
+type gaiaApp struct {
+	*app.BaseApp
+
+	// receives once the block before the halt height of an upgrade this
+	// binary cannot perform is committed, the start command then stops
+	// the node
+	halt chan struct{}
+}
+
+func newGaiaApp(baseApp *app.BaseApp) gaiaApp {
+	return gaiaApp{
+		BaseApp: baseApp,
+		halt:    make(chan struct{}, 1),
+	}
+}
+
+var _ abci.Application = gaiaApp{} // enforce interface at compile time
+
+// BeginBlock - ABCI - migrate the stake store to the layout of this
+// software and run the migration of an upgrade at its halt height, before
+// anything of the block reads the store. Then record the hash of the block
+// for a seeded election policy, it seeds the election of the validators by
+// the tick. Governance takes the bond denomination of stake for the
+// deposits of the block.
+func (a gaiaApp) BeginBlock(req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	ctx := stack.NewContext(
+		a.GetChainID(),
+		a.WorkingHeight(),
+		a.Logger().With("call", "beginblock"),
+	)
+	store := a.Append()
+	stakeStore := stack.PrefixedStore(stake.Name(), store)
+
+	// as for the tick, the node cannot process the block on an error
+	err := stake.MigrateSchema(ctx, stakeStore)
+	if err == nil {
+		err = stake.ApplyUpgrade(ctx, stakeStore)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	stake.SaveElectionSeed(stakeStore, req.Hash)
+	gov.BeginBlock(store)
+	return a.BaseApp.BeginBlock(req)
+}
+
+// Commit - ABCI - commit the block, then halt the node if the next block is
+// at the halt height of an upgrade this binary cannot perform. The state of
+// the committed block is the state the binary of the upgrade restarts on.
+func (a gaiaApp) Commit() abci.ResponseCommit {
+	res := a.BaseApp.Commit()
+	stakeStore := stack.PrefixedStore(stake.Name(), a.Append())
+	if err := stake.CheckUpgrade(stakeStore, a.WorkingHeight()); err != nil {
+		a.Logger().Error("UPGRADE NEEDED: stopping the node, restart it with the software of the upgrade",
+			"err", err.Error())
+		select {
+		case a.halt <- struct{}{}:
+		default: // already halting
+		}
+	}
+	return res
+}
+
+// Query - ABCI - serve the custom query paths of the stake module from the
+// committed state at the height of the query, the other paths are served by
+// the store
+func (a gaiaApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
+	if !strings.HasPrefix(req.Path, stake.QueryPathPrefix) {
+		return a.BaseApp.Query(req)
+	}
+
+	store := &committedStore{app: a.BaseApp.StoreApp, height: req.Height}
+	value, err := stake.Query(stack.PrefixedStore(stake.Name(), store), req.Path)
+	if err == nil {
+		err = store.err
+	}
+	if err != nil {
+		tmErr := sdkerrors.Wrap(err)
+		res.Code = tmErr.ErrorCode()
+		res.Log = tmErr.Error()
+		return
+	}
+	res.Value = value
+	res.Height = store.height
+	return
+}
+
+// committedStore - read-only access to the committed state at a height,
+// through the key queries of the store app. Its height is the height of the
+// first key read, the latest height for height 0.
+type committedStore struct {
+	app    *app.StoreApp
+	height int64
+	err    error
+}
+
+var _ state.SimpleDB = (*committedStore)(nil) // enforce interface at compile time
+
+// Get - read a key of the committed state, the versioned reads need the
+// proofs. A read fails on the code of the query only, the log of a
+// successful query is informational.
+func (s *committedStore) Get(key []byte) []byte {
+	res := s.app.Query(abci.RequestQuery{
+		Path:   "/key",
+		Data:   key,
+		Height: s.height,
+		Prove:  true,
+	})
+	if res.Code != abci.CodeTypeOK {
+		if s.err == nil {
+			s.err = sdkerrors.ErrInternal(fmt.Sprintf("reading key %X: %s", key, res.Log))
+		}
+		return nil
+	}
+	s.height = res.Height
+	return res.Value
+}
+
+// Has - whether a key of the committed state has a value
+func (s *committedStore) Has(key []byte) bool {
+	return s.Get(key) != nil
+}
+
+// nolint - the committed state is read-only and cannot be iterated
+func (s *committedStore) Set(key, value []byte)    { panic("committed state is read-only") }
+func (s *committedStore) Remove(key []byte) []byte { panic("committed state is read-only") }
+func (s *committedStore) List(start, end []byte, limit int) []state.Model {
+	panic("committed state cannot be iterated")
+}
+func (s *committedStore) First(start, end []byte) state.Model {
+	panic("committed state cannot be iterated")
+}
+func (s *committedStore) Last(start, end []byte) state.Model {
+	panic("committed state cannot be iterated")
+}
+func (s *committedStore) Checkpoint() state.SimpleDB  { panic("committed state is read-only") }
+func (s *committedStore) Commit(state.SimpleDB) error { panic("committed state is read-only") }
+func (s *committedStore) Discard()                    {}
+
+// getStartCmd - the start command of the SDK with its flags, running the
+// gaia app. The SDK command builds its own BaseApp, so gaia builds the app
+// and runs it as the SDK does.
+func getStartCmd(tick sdk.Ticker) *cobra.Command {
+	startCmd := basecmd.GetTickStartCmd(tick)
+	startCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return startNode(tick)
+	}
+	return startCmd
+}
+
+func startNode(tick sdk.Ticker) error {
+	logger, err := nodeLogger()
+	if err != nil {
+		return err
+	}
+
+	rootDir := viper.GetString(cli.HomeFlag)
+	appName := fmt.Sprintf("gaia v%v", version.Version)
+	storeApp, err := app.NewStoreApp(
+		appName,
+		path.Join(rootDir, "data", "merkleeyes.db"),
+		basecmd.EyesCacheSize,
+		logger.With("module", "app"))
+	if err != nil {
+		return err
+	}
+	gaia := newGaiaApp(app.NewBaseApp(storeApp, basecmd.Handler, tick))
+
+	// if chain_id has not been set yet, load the genesis.
+	// else, assume it's been loaded
+	if gaia.GetChainID() == "" {
+		genesisFile := path.Join(rootDir, "genesis.json")
+		if _, err := os.Stat(genesisFile); err == nil {
+			err = genesis.Load(gaia, genesisFile)
+			if err != nil {
+				return errors.Errorf("Error in LoadGenesis: %v\n", err)
+			}
+		} else {
+			fmt.Printf("No genesis file at %s, skipping...\n", genesisFile)
+		}
+	}
+
+	// a node stopped for an upgrade restarts with the software of the upgrade
+	stakeStore := stack.PrefixedStore(stake.Name(), gaia.Append())
+	err = stake.CheckUpgrade(stakeStore, gaia.WorkingHeight())
+	if err != nil {
+		return errors.Errorf("UPGRADE NEEDED: %v", err)
+	}
+
+	logger.Info("Starting Gaia", "chain_id", gaia.GetChainID(),
+		"tendermint", !viper.GetBool(basecmd.FlagWithoutTendermint))
+	if viper.GetBool(basecmd.FlagWithoutTendermint) {
+		// run just the abci app, for an external tendermint process
+		svr, err := server.NewServer(viper.GetString(basecmd.FlagAddress), "socket", gaia)
+		if err != nil {
+			return errors.Errorf("Error creating listener: %v\n", err)
+		}
+		svr.SetLogger(logger.With("module", "abci-server"))
+		svr.Start()
+		waitForStop(gaia.halt, logger)
+		return svr.Stop()
+	}
+
+	// run the app with tendermint in-process
+	cfg, err := tcmd.ParseConfig()
+	if err != nil {
+		return err
+	}
+	n, err := node.NewNode(cfg,
+		types.LoadOrGenPrivValidatorFS(cfg.PrivValidatorFile()),
+		proxy.NewLocalClientCreator(gaia),
+		node.DefaultGenesisDocProviderFunc(cfg),
+		node.DefaultDBProvider,
+		logger.With("module", "node"))
+	if err != nil {
+		return err
+	}
+	err = n.Start()
+	if err != nil {
+		return err
+	}
+	waitForStop(gaia.halt, logger)
+	return n.Stop()
+}
+
+// waitForStop - wait for a signal stopping the node, or for the halt of the
+// app before an upgrade
+func waitForStop(halt <-chan struct{}, logger log.Logger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	select {
+	case sig := <-signals:
+		logger.Info("Stopping Gaia", "signal", sig.String())
+	case <-halt:
+		logger.Info("Stopping Gaia for the upgrade")
+	}
+}
+
+// nodeLogger - the logger of the node, at the level set by the flags
+func nodeLogger() (log.Logger, error) {
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "main")
+	logger, err := tmflags.ParseLogLevel(viper.GetString(basecmd.FlagLogLevel), logger, "error")
+	if err != nil {
+		return nil, err
+	}
+	if viper.GetBool(cli.TraceFlag) {
+		logger = log.NewTracingLogger(logger)
+	}
+	return logger, nil
+}
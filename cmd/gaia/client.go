@@ -18,6 +18,7 @@ import (
 	noncecmd "github.com/cosmos/cosmos-sdk/modules/nonce/commands"
 	rolecmd "github.com/cosmos/cosmos-sdk/modules/roles/commands"
 
+	govcmd "github.com/cosmos/gaia/modules/gov/commands"
 	stakecmd "github.com/cosmos/gaia/modules/stake/commands"
 )
 
@@ -48,6 +49,18 @@ func prepareClientCommands() {
 		stakecmd.CmdQueryCandidate,
 		stakecmd.CmdQueryDelegatorBond,
 		stakecmd.CmdQueryDelegatorCandidates,
+		stakecmd.CmdQueryUpgradePlan,
+		stakecmd.CmdQueryUpgradeSignal,
+		stakecmd.CmdQueryEpoch,
+		stakecmd.CmdQueryWithdrawAddress,
+
+		govcmd.CmdQueryProposal,
+		govcmd.CmdQueryActiveProposals,
+		govcmd.CmdQueryDeposit,
+		govcmd.CmdQueryDepositors,
+		govcmd.CmdQueryVote,
+		govcmd.CmdQueryVoters,
+		govcmd.CmdQueryParamChanges,
 	)
 
 	// set up the middleware
@@ -76,6 +89,21 @@ func prepareClientCommands() {
 		stakecmd.CmdEditCandidacy,
 		stakecmd.CmdDelegate,
 		stakecmd.CmdUnbond,
+		stakecmd.CmdSignalUpgrade,
+		stakecmd.CmdRotateConsensusKey,
+		stakecmd.CmdProposeOwner,
+		stakecmd.CmdAcceptOwner,
+		stakecmd.CmdSetOperator,
+		stakecmd.CmdSetWithdrawAddress,
+		stakecmd.CmdRevokeCandidacy,
+		stakecmd.CmdPauseCandidacy,
+		stakecmd.CmdResumeCandidacy,
+		stakecmd.CmdSetDelegationCap,
+
+		govcmd.CmdSubmitProposal,
+		govcmd.CmdSubmitParamChange,
+		govcmd.CmdDeposit,
+		govcmd.CmdVote,
 	)
 
 	clientCmd.AddCommand(
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/cosmos/cosmos-sdk/client/commands"
+
+	scmds "github.com/cosmos/gaia/modules/stake/commands"
+	stakerpc "github.com/cosmos/gaia/modules/stake/rpc"
+)
+
+var (
+	grpcServerCmd = &cobra.Command{
+		Use:   "grpc-server",
+		Short: "gRPC server for gaia commands",
+		Long:  `Serves the stake queries and tx builders of the REST server as a gRPC service.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmdGRPCServer(cmd, args)
+		},
+	}
+)
+
+func prepareGRPCServerCommands() {
+	commands.AddBasicFlags(grpcServerCmd)
+	grpcServerCmd.PersistentFlags().IntP(flagPort, "p", 9099, "port to run the server on")
+}
+
+func cmdGRPCServer(cmd *cobra.Command, args []string) error {
+	addr := fmt.Sprintf(":%d", viper.GetInt(flagPort))
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer()
+	stakerpc.RegisterStakeServer(server,
+		stakerpc.NewServer(scmds.GetClient, scmds.GetTxClient()))
+
+	log.Printf("Serving on %q", addr)
+	return server.Serve(lis)
+}
@@ -31,11 +31,13 @@ func main() {
 	// add commands
 	prepareNodeCommands()
 	prepareRestServerCommands()
+	prepareGRPCServerCommands()
 	prepareClientCommands()
 
 	GaiaCmd.AddCommand(
 		nodeCmd,
 		restServerCmd,
+		grpcServerCmd,
 		clientCmd,
 
 		lineBreak,
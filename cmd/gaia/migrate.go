@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/go-wire"
+	"github.com/tendermint/tmlibs/cli"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/app"
+	basecmd "github.com/cosmos/cosmos-sdk/server/commands"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// migrateCmd reports the migrations the first block processed by this
+// binary would run on the stored state
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Report the stake store migrations this software would run (the node must be stopped)",
+	RunE:  cmdMigrate,
+}
+
+const flagDryRun = "dry-run"
+
+func init() {
+	migrateCmd.Flags().Bool(flagDryRun, false, "Run the migrations on a copy of the state and report the changes")
+}
+
+// changeCounter - records the keys written or removed through a store
+type changeCounter struct {
+	state.SimpleDB
+	written map[string]bool
+	removed map[string]bool
+}
+
+func newChangeCounter(store state.SimpleDB) changeCounter {
+	return changeCounter{
+		SimpleDB: store,
+		written:  make(map[string]bool),
+		removed:  make(map[string]bool),
+	}
+}
+
+func (c changeCounter) Set(key, value []byte) {
+	c.written[string(key)] = true
+	delete(c.removed, string(key))
+	c.SimpleDB.Set(key, value)
+}
+
+func (c changeCounter) Remove(key []byte) []byte {
+	c.removed[string(key)] = true
+	delete(c.written, string(key))
+	return c.SimpleDB.Remove(key)
+}
+
+func cmdMigrate(cmd *cobra.Command, args []string) error {
+	// the migrations must be part of the consensus, so they are only ever
+	// written at the beginning of a block processed by the node
+	if !viper.GetBool(flagDryRun) {
+		return fmt.Errorf("the migrations run in the first block processed by the node, "+
+			"use --%s to report them", flagDryRun)
+	}
+
+	rootDir := viper.GetString(cli.HomeFlag)
+	storeApp, err := app.NewStoreApp("gaia",
+		path.Join(rootDir, "data", "merkleeyes.db"),
+		basecmd.EyesCacheSize,
+		log.NewNopLogger())
+	if err != nil {
+		return err
+	}
+
+	// the checkpoint is discarded, nothing is ever committed
+	store := stack.PrefixedStore(stake.Name(), storeApp.Append().Checkpoint())
+	counter := newChangeCounter(store)
+	height := storeApp.WorkingHeight()
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	var version int64
+	if b := store.Get(stake.SchemaVersionKey); b != nil {
+		err = wire.ReadBinaryBytes(b, &version)
+		if err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Stake store schema version %d, this software writes version %d\n",
+		version, stake.SchemaVersion)
+
+	versions, err := stake.SchemaMigrations(store)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		fmt.Printf("Migration from version %d to %d\n", v, v+1)
+	}
+	err = stake.MigrateSchema(stack.NewContext(storeApp.GetChainID(), height, logger), counter)
+	if err != nil {
+		return err
+	}
+
+	// the upgrade migration runs at the halt height of the plan
+	if b := store.Get(stake.UpgradePlanKey); b != nil {
+		var plan stake.UpgradePlan
+		err = wire.ReadBinaryBytes(b, &plan)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Upgrade %q planned at height %d\n", plan.Name, plan.Height)
+		if plan.Height > height {
+			height = plan.Height
+		}
+		err = stake.ApplyUpgrade(stack.NewContext(storeApp.GetChainID(), height, logger), counter)
+		if stake.IsUpgradeNeededErr(err) {
+			fmt.Println(err)
+		} else if err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("%d keys written, %d keys removed in the stake store\n",
+		len(counter.written), len(counter.removed))
+	return nil
+}
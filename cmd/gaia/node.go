@@ -17,6 +17,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/stack"
 	"github.com/cosmos/cosmos-sdk/state"
 
+	"github.com/cosmos/gaia/modules/gov"
 	"github.com/cosmos/gaia/modules/stake"
 )
 
@@ -27,6 +28,13 @@ var nodeCmd = &cobra.Command{
 	Run:   func(cmd *cobra.Command, args []string) { cmd.Help() },
 }
 
+// stakeHandler - the stake module of the app, its hooks are also called by
+// the tick
+var stakeHandler = stake.NewHandler()
+
+// coinDispatch - route the coins returned by the tick to the coin module
+var coinDispatch = stack.NewDispatcher(coin.NewHandler())
+
 func prepareNodeCommands() {
 
 	basecmd.Handler = stack.New(
@@ -47,13 +55,15 @@ func prepareNodeCommands() {
 			coin.NewHandler(),
 			stack.WrapHandler(roles.NewHandler()),
 			stack.WrapHandler(ibc.NewHandler()),
-			stake.NewHandler(),
+			stakeHandler,
+			gov.NewHandler(),
 		)
 
 	nodeCmd.AddCommand(
 		basecmd.GetInitCmd("fermion", []string{"stake/allowed_bond_denom/fermion"}),
-		basecmd.GetTickStartCmd(sdk.TickerFunc(tickFn)),
+		getStartCmd(sdk.TickerFunc(tickFn)),
 		basecmd.UnsafeResetAllCmd,
+		migrateCmd,
 	)
 }
 
@@ -61,9 +71,32 @@ func prepareNodeCommands() {
 // validator rewards, and calculate the validator set difference
 func tickFn(ctx sdk.Context, store state.SimpleDB) (change []*abci.Validator, err error) {
 	// first need to prefix the store, at this point it's a global store
-	store = stack.PrefixedStore(stake.Name(), store)
+	stakeStore := stack.PrefixedStore(stake.Name(), store)
+
+	// schedule software upgrades, the store was migrated to the layout of
+	// this software and the upgrade of the height was applied by
+	// gaiaApp.BeginBlock. The node stops before the halt height of an
+	// upgrade it cannot perform, see gaiaApp.Commit.
+	err = stake.ProcessUpgrades(ctx, stakeStore)
+	if err != nil {
+		return
+	}
+
+	// close the expired proposals, governance needs the global store
+	// to read the stake and coin state
+	err = gov.EndBlock(ctx, store)
+	if err != nil {
+		return
+	}
+
+	// resume the candidates at the end of their maximum pause
+	err = stake.ProcessPauses(ctx, stakeStore)
+	if err != nil {
+		return
+	}
 
-	// execute Tick
-	change, err = stake.UpdateValidatorSet(store)
+	// apply the bonds queued during the epoch and update the validator set
+	// at the end of the epoch, it needs the global store to return coins
+	change, err = stakeHandler.ProcessEpoch(ctx, store, coinDispatch)
 	return
 }
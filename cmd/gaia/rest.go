@@ -17,6 +17,7 @@ import (
 	noncerest "github.com/cosmos/cosmos-sdk/modules/nonce/rest"
 	rolerest "github.com/cosmos/cosmos-sdk/modules/roles/rest"
 
+	govrest "github.com/cosmos/gaia/modules/gov/rest"
 	stakerest "github.com/cosmos/gaia/modules/stake/rest"
 )
 
@@ -47,6 +48,7 @@ func cmdRestServer(cmd *cobra.Command, args []string) error {
 	keyMan := client.GetKeyManager(rootDir)
 	serviceKeys := rest.NewServiceKeys(keyMan)
 	serviceTxs := rest.NewServiceTxs(commands.GetNode())
+	serviceStakeTxs := stakerest.NewServiceTxs(keyMan)
 
 	routeRegistrars := []func(*mux.Router) error{
 		// rest.Keys handlers
@@ -71,9 +73,23 @@ func cmdRestServer(cmd *cobra.Command, args []string) error {
 		stakerest.RegisterQueryCandidates,
 		stakerest.RegisterQueryDelegatorBond,
 		stakerest.RegisterQueryDelegatorCandidates,
+		stakerest.RegisterQueryUpgradePlan,
+		stakerest.RegisterQueryEpoch,
+		stakerest.RegisterQueryWithdrawAddress,
 		// Staking tx builders
+		stakerest.RegisterDeclareCandidacy,
+		stakerest.RegisterEditCandidacy,
 		stakerest.RegisterDelegate,
 		stakerest.RegisterUnbond,
+		// Staking txs signed by the keys of the server and broadcast
+		serviceStakeTxs.RegisterSendTxs,
+
+		// Governance query handlers
+		govrest.RegisterQueryProposal,
+		govrest.RegisterQueryActiveProposals,
+		govrest.RegisterQueryDeposit,
+		govrest.RegisterQueryVote,
+		govrest.RegisterQueryParamChanges,
 	}
 
 	for _, routeRegistrar := range routeRegistrars {
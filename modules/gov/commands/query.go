@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/client/commands/query"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+
+	"github.com/cosmos/gaia/modules/gov"
+)
+
+// nolint
+var (
+	CmdQueryProposal = &cobra.Command{
+		Use:   "proposal",
+		Short: "Query a governance proposal based on its id",
+		RunE:  cmdQueryProposal,
+	}
+
+	CmdQueryActiveProposals = &cobra.Command{
+		Use:   "active-proposals",
+		Short: "Query the ids of the proposals in their deposit or voting period",
+		RunE:  cmdQueryActiveProposals,
+	}
+
+	CmdQueryDeposit = &cobra.Command{
+		Use:   "deposit",
+		Short: "Query the deposit of an account to a proposal",
+		RunE:  cmdQueryDeposit,
+	}
+
+	CmdQueryDepositors = &cobra.Command{
+		Use:   "depositors",
+		Short: "Query the addresses of all the depositors to a proposal",
+		RunE:  cmdQueryDepositors,
+	}
+
+	CmdQueryVote = &cobra.Command{
+		Use:   "vote",
+		Short: "Query the vote of an account on a proposal",
+		RunE:  cmdQueryVote,
+	}
+
+	CmdQueryVoters = &cobra.Command{
+		Use:   "voters",
+		Short: "Query the addresses of all the voters on a proposal",
+		RunE:  cmdQueryVoters,
+	}
+
+	CmdQueryParamChanges = &cobra.Command{
+		Use:   "param-changes",
+		Short: "Query the record of all the parameter changes applied by governance",
+		RunE:  cmdQueryParamChanges,
+	}
+
+	FlagAddress = "address"
+)
+
+func init() {
+	//Add Flags
+	fsID := flag.NewFlagSet("", flag.ContinueOnError)
+	fsID.Int64(FlagProposalID, 0, "ID of the proposal")
+	fsAddr := flag.NewFlagSet("", flag.ContinueOnError)
+	fsAddr.String(FlagAddress, "", "Depositor or voter Hex Address")
+
+	CmdQueryProposal.Flags().AddFlagSet(fsID)
+	CmdQueryDeposit.Flags().AddFlagSet(fsID)
+	CmdQueryDeposit.Flags().AddFlagSet(fsAddr)
+	CmdQueryDepositors.Flags().AddFlagSet(fsID)
+	CmdQueryVote.Flags().AddFlagSet(fsID)
+	CmdQueryVote.Flags().AddFlagSet(fsAddr)
+	CmdQueryVoters.Flags().AddFlagSet(fsID)
+}
+
+func cmdQueryProposal(cmd *cobra.Command, args []string) error {
+
+	var proposal gov.Proposal
+
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.GetProposalKey(proposalID))
+	height, err := query.GetParsed(key, &proposal, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(proposal, height)
+}
+
+func cmdQueryActiveProposals(cmd *cobra.Command, args []string) error {
+
+	var proposalIDs []int64
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.ActiveProposalsKey)
+	height, err := query.GetParsed(key, &proposalIDs, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(proposalIDs, height)
+}
+
+func cmdQueryDeposit(cmd *cobra.Command, args []string) error {
+
+	var deposit gov.Deposit
+
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+	depositor, err := getAddress()
+	if err != nil {
+		return err
+	}
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.GetDepositKey(proposalID, depositor))
+	height, err := query.GetParsed(key, &deposit, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(deposit, height)
+}
+
+func cmdQueryDepositors(cmd *cobra.Command, args []string) error {
+
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.GetDepositorsKey(proposalID))
+	var depositors []sdk.Actor
+	height, err := query.GetParsed(key, &depositors, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(depositors, height)
+}
+
+func cmdQueryVote(cmd *cobra.Command, args []string) error {
+
+	var vote gov.Vote
+
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+	voter, err := getAddress()
+	if err != nil {
+		return err
+	}
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.GetVoteKey(proposalID, voter))
+	height, err := query.GetParsed(key, &vote, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(vote, height)
+}
+
+func cmdQueryVoters(cmd *cobra.Command, args []string) error {
+
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.GetVotersKey(proposalID))
+	var voters []sdk.Actor
+	height, err := query.GetParsed(key, &voters, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(voters, height)
+}
+
+func cmdQueryParamChanges(cmd *cobra.Command, args []string) error {
+
+	var records []gov.ParamChangeRecord
+
+	prove := !viper.GetBool(commands.FlagTrustNode)
+	key := stack.PrefixedKey(gov.Name(), gov.ParamChangesKey)
+	height, err := query.GetParsed(key, &records, query.GetHeight(), prove)
+	if err != nil {
+		return err
+	}
+
+	return query.OutputProof(records, height)
+}
+
+func getAddress() (actor sdk.Actor, err error) {
+	actor, err = commands.ParseActor(viper.GetString(FlagAddress))
+	if err != nil {
+		return
+	}
+	return coin.ChainAddr(actor), nil
+}
+
+func getProposalID() (int64, error) {
+	proposalID := viper.GetInt64(FlagProposalID)
+	if proposalID <= 0 {
+		return 0, fmt.Errorf("please enter a positive proposal id using --%s", FlagProposalID)
+	}
+	return proposalID, nil
+}
@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	txcmd "github.com/cosmos/cosmos-sdk/client/commands/txs"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+
+	"github.com/cosmos/gaia/modules/gov"
+)
+
+// nolint
+const (
+	FlagProposalID  = "proposal-id"
+	FlagTitle       = "title"
+	FlagDescription = "description"
+	FlagDeposit     = "deposit"
+	FlagOption      = "option"
+	FlagParam       = "param"
+	FlagApplyHeight = "apply-height"
+)
+
+// nolint
+var (
+	CmdSubmitProposal = &cobra.Command{
+		Use:   "submit-proposal",
+		Short: "submit a new governance proposal with an optional initial deposit",
+		RunE:  cmdSubmitProposal,
+	}
+	CmdSubmitParamChange = &cobra.Command{
+		Use:   "submit-param-change",
+		Short: "submit a proposal to change staking parameters at a scheduled height",
+		RunE:  cmdSubmitParamChange,
+	}
+	CmdDeposit = &cobra.Command{
+		Use:   "deposit",
+		Short: "deposit coins to a proposal in its deposit or voting period",
+		RunE:  cmdDeposit,
+	}
+	CmdVote = &cobra.Command{
+		Use:   "vote",
+		Short: "vote on a proposal in its voting period",
+		RunE:  cmdVote,
+	}
+)
+
+func init() {
+
+	// define the flags
+	fsID := flag.NewFlagSet("", flag.ContinueOnError)
+	fsID.Int64(FlagProposalID, 0, "ID of the proposal")
+
+	fsProposal := flag.NewFlagSet("", flag.ContinueOnError)
+	fsProposal.String(FlagTitle, "", "title of the proposal")
+	fsProposal.String(FlagDescription, "", "optional description of the proposal")
+
+	fsDeposit := flag.NewFlagSet("", flag.ContinueOnError)
+	fsDeposit.String(FlagDeposit, "", "Amount of coins to deposit")
+
+	fsParams := flag.NewFlagSet("", flag.ContinueOnError)
+	fsParams.StringSlice(FlagParam, nil, "staking parameter change as key=value, keyed as in the genesis")
+	fsParams.Int64(FlagApplyHeight, 0, "height at which the changes are applied if the proposal passes")
+
+	fsOption := flag.NewFlagSet("", flag.ContinueOnError)
+	fsOption.String(FlagOption, "", "vote option: yes, abstain, no or no_with_veto")
+
+	// add the flags
+	CmdSubmitProposal.Flags().AddFlagSet(fsProposal)
+	CmdSubmitProposal.Flags().AddFlagSet(fsDeposit)
+
+	CmdSubmitParamChange.Flags().AddFlagSet(fsProposal)
+	CmdSubmitParamChange.Flags().AddFlagSet(fsDeposit)
+	CmdSubmitParamChange.Flags().AddFlagSet(fsParams)
+
+	CmdDeposit.Flags().AddFlagSet(fsID)
+	CmdDeposit.Flags().AddFlagSet(fsDeposit)
+
+	CmdVote.Flags().AddFlagSet(fsID)
+	CmdVote.Flags().AddFlagSet(fsOption)
+}
+
+func cmdSubmitProposal(cmd *cobra.Command, args []string) error {
+	deposit, err := getInitialDeposit()
+	if err != nil {
+		return err
+	}
+
+	tx := gov.NewTxSubmitProposal(viper.GetString(FlagTitle),
+		viper.GetString(FlagDescription), deposit)
+	return txcmd.DoTx(tx)
+}
+
+func cmdSubmitParamChange(cmd *cobra.Command, args []string) error {
+	deposit, err := getInitialDeposit()
+	if err != nil {
+		return err
+	}
+
+	var changes []gov.ParamChange
+	for _, param := range viper.GetStringSlice(FlagParam) {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("param change must be formatted as key=value, got %q", param)
+		}
+		changes = append(changes, gov.ParamChange{Key: kv[0], Value: kv[1]})
+	}
+	if len(changes) == 0 {
+		return fmt.Errorf("please enter at least one param change using --%s", FlagParam)
+	}
+
+	tx := gov.NewTxSubmitParamChangeProposal(viper.GetString(FlagTitle),
+		viper.GetString(FlagDescription), deposit, changes, viper.GetInt64(FlagApplyHeight))
+	return txcmd.DoTx(tx)
+}
+
+// getInitialDeposit - check the title and parse the optional deposit of a new proposal
+func getInitialDeposit() (deposit coin.Coin, err error) {
+	if viper.GetString(FlagTitle) == "" {
+		err = fmt.Errorf("please enter a title for the proposal using --%s", FlagTitle)
+		return
+	}
+	if viper.GetString(FlagDeposit) != "" {
+		deposit, err = coin.ParseCoin(viper.GetString(FlagDeposit))
+	}
+	return
+}
+
+func cmdDeposit(cmd *cobra.Command, args []string) error {
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+
+	amount, err := coin.ParseCoin(viper.GetString(FlagDeposit))
+	if err != nil {
+		return err
+	}
+
+	tx := gov.NewTxDeposit(proposalID, amount)
+	return txcmd.DoTx(tx)
+}
+
+func cmdVote(cmd *cobra.Command, args []string) error {
+	proposalID, err := getProposalID()
+	if err != nil {
+		return err
+	}
+
+	option, err := gov.ParseVoteOption(viper.GetString(FlagOption))
+	if err != nil {
+		return err
+	}
+
+	tx := gov.NewTxVote(proposalID, option)
+	return txcmd.DoTx(tx)
+}
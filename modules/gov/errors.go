@@ -0,0 +1,44 @@
+// nolint
+package gov
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/errors"
+)
+
+var (
+	errEmptyTitle       = fmt.Errorf("Proposal must have a title")
+	errBadProposalID    = fmt.Errorf("Proposal id must be > 0")
+	errBadVoteOption    = fmt.Errorf("Invalid vote option")
+	errBadDepositDenom  = fmt.Errorf("Invalid deposit denomination")
+	errMissingSignature = fmt.Errorf("Missing signature")
+	errMultipleRoles    = fmt.Errorf("Cannot assume more than one role")
+	errUnknownProposal  = fmt.Errorf("Proposal does not exist")
+	errInactiveProposal = fmt.Errorf("Proposal is no longer active")
+	errNotVotingPeriod  = fmt.Errorf("Proposal is not in its voting period")
+	errNoParamChanges   = fmt.Errorf("Proposal must change at least one parameter")
+	errBadApplyHeight   = fmt.Errorf("Parameter changes must be applied after the end of the voting period")
+)
+
+func ErrBadDepositDenom() error {
+	return errors.WithCode(errBadDepositDenom, errors.CodeTypeBaseInvalidInput)
+}
+func ErrMissingSignature() error {
+	return errors.WithCode(errMissingSignature, errors.CodeTypeUnauthorized)
+}
+func ErrMultipleRoles() error {
+	return errors.WithCode(errMultipleRoles, errors.CodeTypeUnauthorized)
+}
+func ErrUnknownProposal() error {
+	return errors.WithCode(errUnknownProposal, errors.CodeTypeBaseUnknownAddress)
+}
+func ErrInactiveProposal() error {
+	return errors.WithCode(errInactiveProposal, errors.CodeTypeBaseInvalidInput)
+}
+func ErrNotVotingPeriod() error {
+	return errors.WithCode(errNotVotingPeriod, errors.CodeTypeBaseInvalidInput)
+}
+func ErrBadApplyHeight() error {
+	return errors.WithCode(errBadApplyHeight, errors.CodeTypeBaseInvalidInput)
+}
@@ -0,0 +1,570 @@
+package gov
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/errors"
+	"github.com/cosmos/cosmos-sdk/modules/auth"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/modules/roles"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// nolint
+const govModuleName = "gov"
+
+// Name is the name of the modules.
+func Name() string {
+	return govModuleName
+}
+
+//_______________________________________________________________________
+
+// governance - interface to enforce the proposal lifecycle
+type governance interface {
+	submitProposal(TxSubmitProposal) error
+	submitParamChangeProposal(TxSubmitParamChangeProposal) error
+	deposit(TxDeposit) error
+	vote(TxVote) error
+}
+
+//_______________________________________________________________________
+
+// Handler - the transaction processing handler
+type Handler struct {
+	stack.PassInitValidate
+}
+
+var _ stack.Dispatchable = Handler{} // enforce interface at compile time
+
+// NewHandler returns a new Handler with the default Params
+func NewHandler() Handler {
+	return Handler{}
+}
+
+// Name - return gov namespace
+func (Handler) Name() string {
+	return govModuleName
+}
+
+// AssertDispatcher - placeholder for stack.Dispatchable
+func (Handler) AssertDispatcher() {}
+
+// InitState - set genesis parameters for governance
+func (h Handler) InitState(l log.Logger, store state.SimpleDB,
+	module, key, value string, cb sdk.InitStater) (log string, err error) {
+	return "", h.initState(module, key, value, store)
+}
+
+// separated for testing
+func (Handler) initState(module, key, value string, store state.SimpleDB) error {
+	if module != govModuleName {
+		return errors.ErrUnknownModule(module)
+	}
+
+	params := loadParams(store)
+	switch key {
+	case "min_deposit",
+		"max_deposit_period",
+		"voting_period",
+		"quorum",
+		"threshold",
+		"veto":
+
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("input must be integer, Error: %v", err.Error())
+		}
+		if i < 0 {
+			return fmt.Errorf("input must be a non-negative integer, got %v", i)
+		}
+
+		switch key {
+		case "min_deposit":
+			params.MinDeposit = i
+		case "max_deposit_period":
+			params.MaxDepositPeriod = i
+		case "voting_period":
+			params.VotingPeriod = i
+		case "quorum":
+			params.Quorum = i
+		case "threshold":
+			params.Threshold = i
+		case "veto":
+			params.Veto = i
+		}
+	default:
+		return errors.ErrUnknownKey(key)
+	}
+
+	saveParams(store, params)
+	return nil
+}
+
+// CheckTx checks if the tx is properly structured
+func (h Handler) CheckTx(ctx sdk.Context, store state.SimpleDB,
+	tx sdk.Tx, _ sdk.Checker) (res sdk.CheckResult, err error) {
+
+	err = tx.ValidateBasic()
+	if err != nil {
+		return res, err
+	}
+
+	// get the sender
+	sender, err := getTxSender(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	params := loadParams(store)
+	checker := check{
+		store:  store,
+		sender: sender,
+		params: params,
+		height: ctx.BlockHeight(),
+	}
+
+	// return the fee for each tx type
+	switch txInner := tx.Unwrap().(type) {
+	case TxSubmitProposal:
+		return sdk.NewCheck(params.GasSubmitProposal, ""),
+			checker.submitProposal(txInner)
+	case TxSubmitParamChangeProposal:
+		return sdk.NewCheck(params.GasSubmitProposal, ""),
+			checker.submitParamChangeProposal(txInner)
+	case TxDeposit:
+		return sdk.NewCheck(params.GasDeposit, ""),
+			checker.deposit(txInner)
+	case TxVote:
+		return sdk.NewCheck(params.GasVote, ""),
+			checker.vote(txInner)
+	}
+
+	return res, errors.ErrUnknownTxType(tx)
+}
+
+// DeliverTx executes the tx if valid
+func (h Handler) DeliverTx(ctx sdk.Context, store state.SimpleDB,
+	tx sdk.Tx, dispatch sdk.Deliver) (res sdk.DeliverResult, err error) {
+
+	_, err = h.CheckTx(ctx, store, tx, nil)
+	if err != nil {
+		return
+	}
+
+	sender, err := getTxSender(ctx)
+	if err != nil {
+		return
+	}
+
+	params := loadParams(store)
+	deliverer := deliver{
+		store:  store,
+		sender: sender,
+		params: params,
+		height: ctx.BlockHeight(),
+		transfer: coinSender{
+			store:    store,
+			dispatch: dispatch,
+			ctx:      ctx,
+		}.transferFn,
+	}
+
+	// Run the transaction
+	switch _tx := tx.Unwrap().(type) {
+	case TxSubmitProposal:
+		res.GasUsed = params.GasSubmitProposal
+		return res, deliverer.submitProposal(_tx)
+	case TxSubmitParamChangeProposal:
+		res.GasUsed = params.GasSubmitProposal
+		return res, deliverer.submitParamChangeProposal(_tx)
+	case TxDeposit:
+		res.GasUsed = params.GasDeposit
+		return res, deliverer.deposit(_tx)
+	case TxVote:
+		res.GasUsed = params.GasVote
+		return res, deliverer.vote(_tx)
+	}
+	return
+}
+
+// get the sender from the ctx, the role assumed through the roles middleware
+// if any, otherwise the single signer of the tx. A role lets a multisig
+// deposit and vote.
+func getTxSender(ctx sdk.Context) (sender sdk.Actor, err error) {
+	assumed := ctx.GetPermissions("", roles.NameRole)
+	switch len(assumed) {
+	case 0:
+	case 1:
+		return assumed[0], nil
+	default:
+		return sender, ErrMultipleRoles()
+	}
+
+	senders := ctx.GetPermissions("", auth.NameSigs)
+	if len(senders) != 1 {
+		return sender, ErrMissingSignature()
+	}
+	return senders[0], nil
+}
+
+//_______________________________________________________________________
+
+type coinSender struct {
+	store    state.SimpleDB
+	dispatch sdk.Deliver
+	ctx      sdk.Context
+}
+
+func (c coinSender) transferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
+	send := coin.NewSendOneTx(sender, receiver, coins)
+
+	// If the deduction fails (too high), abort the command
+	_, err := c.dispatch.DeliverTx(c.ctx, c.store, send)
+	return err
+}
+
+type transferFn func(sender, receiver sdk.Actor, coins coin.Coins) error
+
+//_____________________________________________________________________
+
+type check struct {
+	store  state.SimpleDB
+	sender sdk.Actor
+	params Params
+	height int64
+}
+
+var _ governance = check{} // enforce interface at compile time
+
+func (c check) submitProposal(tx TxSubmitProposal) error {
+	if tx.InitialDeposit.Amount > 0 {
+		return c.checkDenom(tx.InitialDeposit)
+	}
+	return nil
+}
+
+func (c check) submitParamChangeProposal(tx TxSubmitParamChangeProposal) error {
+	// the voting period of the proposal must be over by the apply height,
+	// even if the min deposit is only reached at the end of the deposit period
+	if tx.ApplyHeight < c.height+c.params.MaxDepositPeriod+c.params.VotingPeriod {
+		return ErrBadApplyHeight()
+	}
+
+	// the changes are validated on the current stake parameters
+	stakeParams := loadStakeParams(c.store)
+	for _, change := range tx.ParamChanges {
+		err := stakeParams.ValidateParam(change.Key, change.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return c.submitProposal(tx.TxSubmitProposal)
+}
+
+func (c check) deposit(tx TxDeposit) error {
+	proposal := loadProposal(c.store, tx.ProposalID)
+	if proposal == nil {
+		return ErrUnknownProposal()
+	}
+	if !proposal.isActive() {
+		return ErrInactiveProposal()
+	}
+
+	// the deposits of a proposal stay in the denomination it was submitted
+	// with, even if the bond denomination changed since
+	if tx.Amount.Denom != proposal.TotalDeposit.Denom {
+		return ErrBadDepositDenom()
+	}
+	return nil
+}
+
+func (c check) vote(tx TxVote) error {
+	proposal := loadProposal(c.store, tx.ProposalID)
+	if proposal == nil {
+		return ErrUnknownProposal()
+	}
+	if proposal.Status != StatusVotingPeriod {
+		return ErrNotVotingPeriod()
+	}
+	return nil
+}
+
+func (c check) checkDenom(amount coin.Coin) error {
+	if amount.Denom != c.params.BondDenom {
+		return ErrBadDepositDenom()
+	}
+	return nil
+}
+
+//_____________________________________________________________________
+
+type deliver struct {
+	store    state.SimpleDB
+	sender   sdk.Actor
+	params   Params
+	height   int64
+	transfer transferFn
+}
+
+var _ governance = deliver{} // enforce interface at compile time
+
+// These functions assume everything has been authenticated,
+// now we just perform action and save
+func (d deliver) submitProposal(tx TxSubmitProposal) error {
+	return d.newProposal(tx, nil, 0)
+}
+
+func (d deliver) submitParamChangeProposal(tx TxSubmitParamChangeProposal) error {
+	return d.newProposal(tx.TxSubmitProposal, tx.ParamChanges, tx.ApplyHeight)
+}
+
+func (d deliver) newProposal(tx TxSubmitProposal,
+	paramChanges []ParamChange, applyHeight int64) error {
+
+	proposal := &Proposal{
+		ProposalID:   nextProposalID(d.store),
+		Title:        tx.Title,
+		Description:  tx.Description,
+		Proposer:     d.sender,
+		Status:       StatusDepositPeriod,
+		SubmitHeight: d.height,
+		TotalDeposit: coin.Coin{Denom: d.params.BondDenom},
+		ParamChanges: paramChanges,
+		ApplyHeight:  applyHeight,
+	}
+
+	// without a min deposit the voting period begins at the submission
+	if proposal.TotalDeposit.Amount >= d.params.MinDeposit {
+		proposal.Status = StatusVotingPeriod
+		proposal.VotingStartHeight = d.height
+	}
+
+	saveProposal(d.store, proposal)
+	saveActiveProposalIDs(d.store,
+		append(loadActiveProposalIDs(d.store), proposal.ProposalID))
+
+	if tx.InitialDeposit.Amount == 0 {
+		return nil
+	}
+	return d.deposit(TxDeposit{proposal.ProposalID, tx.InitialDeposit})
+}
+
+func (d deliver) deposit(tx TxDeposit) error {
+
+	proposal := loadProposal(d.store, tx.ProposalID)
+	if proposal == nil {
+		return ErrUnknownProposal()
+	}
+	if !proposal.isActive() {
+		return ErrInactiveProposal()
+	}
+
+	// Move coins from the depositor account to the hold account
+	err := d.transfer(d.sender, d.params.HoldAccount, coin.Coins{tx.Amount})
+	if err != nil {
+		return err
+	}
+
+	deposit := loadDeposit(d.store, tx.ProposalID, d.sender)
+	if deposit == nil {
+		deposit = &Deposit{
+			Depositor: d.sender,
+			Amount:    coin.Coin{Denom: tx.Amount.Denom},
+		}
+	}
+	deposit.Amount.Amount += tx.Amount.Amount
+	proposal.TotalDeposit.Amount += tx.Amount.Amount
+
+	// the voting period begins as soon as the min deposit is reached
+	if proposal.Status == StatusDepositPeriod &&
+		proposal.TotalDeposit.Amount >= d.params.MinDeposit {
+
+		proposal.Status = StatusVotingPeriod
+		proposal.VotingStartHeight = d.height
+	}
+
+	saveDeposit(d.store, tx.ProposalID, deposit)
+	saveProposal(d.store, proposal)
+	return nil
+}
+
+func (d deliver) vote(tx TxVote) error {
+
+	proposal := loadProposal(d.store, tx.ProposalID)
+	if proposal == nil {
+		return ErrUnknownProposal()
+	}
+	if proposal.Status != StatusVotingPeriod {
+		return ErrNotVotingPeriod()
+	}
+
+	// a new vote replaces any previous vote of the sender
+	saveVote(d.store, tx.ProposalID, &Vote{
+		Voter:  d.sender,
+		Option: tx.Option,
+	})
+	return nil
+}
+
+//_____________________________________________________________________
+
+// BeginBlock - follow the stake parameters: the deposits are made in the coin
+// which is bonded, and the parameter changes are validated on the current
+// stake parameters. Governance needs the global store to read the stake
+// parameters. It is called before the txs of the block, the parameters are
+// only written when they changed.
+func BeginBlock(store state.SimpleDB) {
+	govStore := stack.PrefixedStore(govModuleName, store)
+	stakeStore := stack.PrefixedStore(stake.Name(), store)
+
+	params := loadParams(govStore)
+	stakeParams := stake.NewView(stakeStore).Params()
+	saveStakeParams(govStore, stakeParams)
+	if params.BondDenom != stakeParams.AllowedBondDenom {
+		params.BondDenom = stakeParams.AllowedBondDenom
+		saveParams(govStore, params)
+	}
+}
+
+// EndBlock - close the deposit and voting periods of the active proposals
+// which have expired at this height, and apply the parameter changes of
+// passed proposals scheduled up to this height. The store must be the global
+// store, as the tally reads the bonded shares from the stake module's state
+// space and the deposits are returned through the coin module's state space.
+func EndBlock(ctx sdk.Context, store state.SimpleDB) error {
+	govStore := stack.PrefixedStore(govModuleName, store)
+	stakeStore := stack.PrefixedStore(stake.Name(), store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+
+	height := ctx.BlockHeight()
+	params := loadParams(govStore)
+
+	var active []int64
+	proposalIDs := loadActiveProposalIDs(govStore)
+	for _, proposalID := range proposalIDs {
+		proposal := loadProposal(govStore, proposalID)
+
+		refund := true
+		switch proposal.Status {
+		case StatusDepositPeriod:
+			if height < proposal.SubmitHeight+params.MaxDepositPeriod {
+				active = append(active, proposalID)
+				continue
+			}
+			proposal.Status = StatusRejected
+		case StatusVotingPeriod:
+			if height < proposal.VotingStartHeight+params.VotingPeriod {
+				active = append(active, proposalID)
+				continue
+			}
+			proposal.TallyResult = tally(govStore, stakeStore, proposalID)
+			passes, vetoed := proposal.TallyResult.passes(params)
+			if passes {
+				proposal.Status = StatusPassed
+				if len(proposal.ParamChanges) > 0 {
+					savePendingParamChanges(govStore,
+						append(loadPendingParamChanges(govStore), proposalID))
+				}
+			} else {
+				proposal.Status = StatusRejected
+			}
+			refund = !vetoed
+		}
+		saveProposal(govStore, proposal)
+
+		settleDeposits(ctx, govStore, coinStore, params, proposalID, refund)
+		ctx.Info("Proposal closed", "proposal_id", proposalID,
+			"status", proposal.Status.String(), "refunded", refund)
+	}
+
+	// only write when a proposal was closed, to not change the app hash every block
+	if len(active) != len(proposalIDs) {
+		saveActiveProposalIDs(govStore, active)
+	}
+	applyParamChanges(ctx, govStore, stakeStore)
+	return nil
+}
+
+// applyParamChanges - save the staking parameter changes of the passed
+// proposals which are due and record them. The changes are validated again
+// on the current stake parameters: the changes of a proposal are all applied
+// or all rejected, a rejection must not halt the chain.
+func applyParamChanges(ctx sdk.Context, store, stakeStore state.SimpleDB) {
+	height := ctx.BlockHeight()
+
+	var pending []int64
+	proposalIDs := loadPendingParamChanges(store)
+	for _, proposalID := range proposalIDs {
+		proposal := loadProposal(store, proposalID)
+		if height < proposal.ApplyHeight {
+			pending = append(pending, proposalID)
+			continue
+		}
+
+		cp := stakeStore.Checkpoint()
+		var err error
+		for _, change := range proposal.ParamChanges {
+			err = stake.SetParam(cp, change.Key, change.Value)
+			if err != nil {
+				ctx.Error("Param change failed", "proposal_id", proposalID,
+					"key", change.Key, "value", change.Value, "err", err)
+				break
+			}
+		}
+		if err == nil {
+			err = stakeStore.Commit(cp)
+		}
+		if err != nil {
+			continue
+		}
+
+		for _, change := range proposal.ParamChanges {
+			recordParamChange(store, ParamChangeRecord{
+				ProposalID: proposalID,
+				Height:     height,
+				Key:        change.Key,
+				Value:      change.Value,
+			})
+			ctx.Info("Param changed", "proposal_id", proposalID,
+				"key", change.Key, "value", change.Value)
+		}
+	}
+	if len(pending) != len(proposalIDs) {
+		savePendingParamChanges(store, pending)
+	}
+}
+
+// settleDeposits either returns the deposits of a closed proposal to their
+// depositors, or burns them from the hold account if the proposal was vetoed.
+// A deposit which cannot be settled is logged and stays in the hold account,
+// it must not stop the chain.
+func settleDeposits(ctx sdk.Context, store, coinStore state.SimpleDB,
+	params Params, proposalID int64, refund bool) {
+
+	for _, depositor := range loadDepositors(store, proposalID) {
+		deposit := loadDeposit(store, proposalID, depositor)
+		coins := coin.Coins{deposit.Amount}
+
+		// the hold account is only debited if the refund succeeds
+		cp := coinStore.Checkpoint()
+		_, err := coin.ChangeCoins(cp, params.HoldAccount, coins.Negative())
+		if err == nil && refund {
+			_, err = coin.ChangeCoins(cp, depositor, coins)
+		}
+		if err == nil {
+			err = coinStore.Commit(cp)
+		}
+		if err != nil {
+			ctx.Error("Deposit not settled", "proposal_id", proposalID,
+				"depositor", depositor, "amount", deposit.Amount.String(), "err", err.Error())
+		}
+	}
+}
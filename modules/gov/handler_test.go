@@ -0,0 +1,304 @@
+package gov
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/go-wire"
+	"github.com/tendermint/tmlibs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+//______________________________________________________________________
+
+type testCoinSender struct {
+	store map[string]int64
+}
+
+func (c testCoinSender) transferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
+	if c.store[string(sender.Address)] < coins[0].Amount {
+		return coin.ErrInsufficientFunds()
+	}
+	c.store[string(sender.Address)] -= coins[0].Amount
+	c.store[string(receiver.Address)] += coins[0].Amount
+	return nil
+}
+
+func newActors(n int) (actors []sdk.Actor) {
+	for i := 0; i < n; i++ {
+		actors = append(actors, sdk.Actor{
+			"testChain", "testapp", []byte(fmt.Sprintf("addr%d", i))})
+	}
+	return
+}
+
+func initAccounts(n int, amount int64) ([]sdk.Actor, map[string]int64) {
+	accStore := map[string]int64{}
+	senders := newActors(n)
+	for _, sender := range senders {
+		accStore[string(sender.Address)] = amount
+	}
+	return senders, accStore
+}
+
+func newDeliver(store state.SimpleDB, sender sdk.Actor, accStore map[string]int64) deliver {
+	return deliver{
+		store:    store,
+		sender:   sender,
+		params:   loadParams(store),
+		height:   1,
+		transfer: testCoinSender{accStore}.transferFn,
+	}
+}
+
+func fermions(amt int64) coin.Coin {
+	return coin.Coin{Denom: "fermion", Amount: amt}
+}
+
+//______________________________________________________________________
+
+func TestSubmitProposalAndDeposit(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	store := state.NewMemKVStore()
+	deliverer := newDeliver(store, senders[0], accStore)
+	minDeposit := deliverer.params.MinDeposit
+
+	// submit a proposal short of the min deposit
+	got := deliverer.submitProposal(TxSubmitProposal{"title", "description", fermions(minDeposit - 10)})
+	require.NoError(got)
+	proposal := loadProposal(store, 1)
+	require.NotNil(proposal)
+	assert.Equal(StatusDepositPeriod, proposal.Status)
+	assert.Equal(minDeposit-10, proposal.TotalDeposit.Amount)
+	assert.Equal([]int64{1}, loadActiveProposalIDs(store))
+
+	// votes are not accepted during the deposit period
+	checker := check{store, senders[1], deliverer.params, 1}
+	assert.Error(checker.vote(TxVote{1, OptionYes}))
+
+	// another account completes the deposit
+	deliverer.sender = senders[1]
+	deliverer.height = 5
+	got = deliverer.deposit(TxDeposit{1, fermions(10)})
+	require.NoError(got)
+	proposal = loadProposal(store, 1)
+	assert.Equal(StatusVotingPeriod, proposal.Status)
+	assert.Equal(int64(5), proposal.VotingStartHeight)
+	assert.Equal(2, len(loadDepositors(store, 1)))
+	assert.Equal(int64(10), loadDeposit(store, 1, senders[1]).Amount.Amount)
+	assert.Equal(minDeposit, accStore[string(deliverer.params.HoldAccount.Address)])
+
+	// deposits must be in the bond denom
+	assert.Error(checker.deposit(TxDeposit{1, coin.Coin{"foo", 10}}))
+	assert.Error(checker.deposit(TxDeposit{2, fermions(10)}))
+
+	// votes can now be cast and changed
+	assert.NoError(checker.vote(TxVote{1, OptionYes}))
+	require.NoError(deliverer.vote(TxVote{1, OptionYes}))
+	require.NoError(deliverer.vote(TxVote{1, OptionNo}))
+	assert.Equal(1, len(loadVoters(store, 1)))
+	assert.Equal(OptionNo, loadVote(store, 1, senders[1]).Option)
+
+	// the ids are incremented
+	require.NoError(deliverer.submitProposal(TxSubmitProposal{"second", "", fermions(0)}))
+	assert.NotNil(loadProposal(store, 2))
+	assert.Equal([]int64{1, 2}, loadActiveProposalIDs(store))
+
+	// without a min deposit the voting period begins at the submission
+	deliverer.params.MinDeposit = 0
+	deliverer.height = 7
+	require.NoError(deliverer.submitProposal(TxSubmitProposal{"free", "", fermions(0)}))
+	proposal = loadProposal(store, 3)
+	assert.Equal(StatusVotingPeriod, proposal.Status)
+	assert.Equal(int64(7), proposal.VotingStartHeight)
+}
+
+func TestBeginBlock(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	govStore := stack.PrefixedStore(govModuleName, store)
+	stakeStore := stack.PrefixedStore(stake.Name(), store)
+
+	senders, accStore := initAccounts(1, 1000)
+	deliverer := newDeliver(govStore, senders[0], accStore)
+	require.NoError(deliverer.submitProposal(TxSubmitProposal{"fermions", "", fermions(10)}))
+
+	// the deposits are made in the bond denomination of stake
+	require.NoError(stake.SetParam(stakeStore, "allowed_bond_denom", "atom"))
+	BeginBlock(store)
+	assert.Equal("atom", loadParams(govStore).BondDenom)
+	assert.Equal(stake.NewView(stakeStore).Params(), loadStakeParams(govStore))
+
+	checker := check{govStore, senders[0], loadParams(govStore), 1}
+	assert.Error(checker.submitProposal(TxSubmitProposal{"atoms", "", fermions(10)}))
+	assert.NoError(checker.submitProposal(TxSubmitProposal{"atoms", "", coin.Coin{"atom", 10}}))
+
+	// a proposal keeps the denomination it was submitted with
+	assert.NoError(checker.deposit(TxDeposit{1, fermions(10)}))
+	assert.Error(checker.deposit(TxDeposit{1, coin.Coin{"atom", 10}}))
+}
+
+func TestSettleDepositsFailure(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	accounts, accStore := initAccounts(1, 1000)
+	depositor := accounts[0]
+
+	store := state.NewMemKVStore()
+	govStore := stack.PrefixedStore(govModuleName, store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+	params := loadParams(govStore)
+	deliverer := newDeliver(govStore, depositor, accStore)
+	require.NoError(deliverer.submitProposal(TxSubmitProposal{"short", "", fermions(10)}))
+
+	// the hold account cannot refund the deposit, the proposal is closed
+	// without stopping the chain and the coins stay in the hold account
+	_, err := coin.ChangeCoins(coinStore, params.HoldAccount, coin.Coins{fermions(5)})
+	require.NoError(err)
+	ctx := stack.NewContext("testChain", params.MaxDepositPeriod+1, log.NewNopLogger())
+	require.NoError(EndBlock(ctx, store))
+	assert.Equal(StatusRejected, loadProposal(govStore, 1).Status)
+	assert.Zero(len(loadActiveProposalIDs(govStore)))
+
+	hold, err := coin.GetAccount(coinStore, params.HoldAccount)
+	require.NoError(err)
+	assert.Equal(coin.Coins{fermions(5)}, hold.Coins)
+	acc, err := coin.GetAccount(coinStore, depositor)
+	require.NoError(err)
+	assert.Zero(len(acc.Coins))
+}
+
+func TestEndBlock(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	accounts, accStore := initAccounts(3, 1000)
+	owner, delegator, depositor := accounts[0], accounts[1], accounts[2]
+
+	store := state.NewMemKVStore()
+	govStore := stack.PrefixedStore(govModuleName, store)
+	stakeStore := stack.PrefixedStore("stake", store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+
+	params := loadParams(govStore)
+	deliverer := newDeliver(govStore, depositor, accStore)
+
+	// the deposits are held by the hold account in the coin store
+	_, err := coin.ChangeCoins(coinStore, params.HoldAccount, coin.Coins{fermions(2 * params.MinDeposit)})
+	require.NoError(err)
+
+	// one proposal reaches the voting period, the other does not
+	require.NoError(deliverer.submitProposal(TxSubmitProposal{"vote", "", fermions(params.MinDeposit)}))
+	require.NoError(deliverer.submitProposal(TxSubmitProposal{"deposit", "", fermions(params.MinDeposit - 1)}))
+
+	// a candidate with a delegator who overrides the owner's vote
+	saveTestCandidate(stakeStore, pks[0], owner, 30)
+	saveTestBond(stakeStore, owner, pks[0], 10)
+	saveTestBond(stakeStore, delegator, pks[0], 20)
+
+	deliverer.sender = owner
+	require.NoError(deliverer.vote(TxVote{1, OptionNo}))
+	deliverer.sender = delegator
+	require.NoError(deliverer.vote(TxVote{1, OptionYes}))
+
+	// nothing happens before the end of the periods
+	ctx := stack.NewContext("testChain", params.VotingPeriod, log.NewNopLogger())
+	require.NoError(EndBlock(ctx, store))
+	assert.Equal([]int64{1, 2}, loadActiveProposalIDs(govStore))
+
+	ctx = stack.NewContext("testChain", params.VotingPeriod+1, log.NewNopLogger())
+	require.NoError(EndBlock(ctx, store))
+	assert.Zero(len(loadActiveProposalIDs(govStore)))
+
+	proposal := loadProposal(govStore, 1)
+	assert.Equal(StatusPassed, proposal.Status)
+	assert.Equal(TallyResult{Yes: 20, No: 10, TotalShares: 30}, proposal.TallyResult)
+	assert.Equal(StatusRejected, loadProposal(govStore, 2).Status)
+
+	// all the deposits have been refunded
+	acc, err := coin.GetAccount(coinStore, depositor)
+	require.NoError(err)
+	assert.Equal(coin.Coins{fermions(2*params.MinDeposit - 1)}, acc.Coins)
+}
+
+func TestParamChangeProposal(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	accounts, accStore := initAccounts(1, 1000)
+	owner := accounts[0]
+
+	store := state.NewMemKVStore()
+	govStore := stack.PrefixedStore(govModuleName, store)
+	stakeStore := stack.PrefixedStore("stake", store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+
+	params := loadParams(govStore)
+	votingEnd := params.VotingPeriod + 1
+	applyHeight := params.MaxDepositPeriod + params.VotingPeriod + 10
+	_, err := coin.ChangeCoins(coinStore, params.HoldAccount, coin.Coins{fermions(params.MinDeposit)})
+	require.NoError(err)
+
+	// the changes are validated on the current stake params
+	changes := []ParamChange{{"max_vals", "2"}, {"gas_bond", "5"}}
+	tx := TxSubmitParamChangeProposal{
+		TxSubmitProposal{"vals", "", fermions(params.MinDeposit)}, changes, applyHeight}
+	assert.NoError(tx.ValidateBasic())
+	bad := tx
+	bad.ParamChanges = nil
+	assert.Error(bad.ValidateBasic())
+
+	BeginBlock(store)
+	checker := check{govStore, owner, params, 1}
+	assert.NoError(checker.submitParamChangeProposal(tx))
+	bad = tx
+	bad.ParamChanges = []ParamChange{{"max_vals", "-2"}}
+	assert.Error(checker.submitParamChangeProposal(bad))
+
+	// the apply height must be after the latest possible end of the vote
+	bad = tx
+	bad.ApplyHeight = params.MaxDepositPeriod + params.VotingPeriod
+	assert.Error(checker.submitParamChangeProposal(bad))
+
+	deliverer := newDeliver(govStore, owner, accStore)
+	require.NoError(deliverer.submitParamChangeProposal(tx))
+	saveTestCandidate(stakeStore, pks[0], owner, 10)
+	saveTestBond(stakeStore, owner, pks[0], 10)
+	require.NoError(deliverer.vote(TxVote{1, OptionYes}))
+
+	// the proposal passes but the changes wait for the apply height
+	require.NoError(EndBlock(stack.NewContext("testChain", votingEnd, log.NewNopLogger()), store))
+	assert.Equal(StatusPassed, loadProposal(govStore, 1).Status)
+	assert.Equal([]int64{1}, loadPendingParamChanges(govStore))
+	assert.Nil(stakeStore.Get(stake.ParamKey))
+
+	require.NoError(EndBlock(stack.NewContext("testChain", applyHeight, log.NewNopLogger()), store))
+	assert.Zero(len(loadPendingParamChanges(govStore)))
+
+	var stakeParams stake.Params
+	require.NoError(wire.ReadBinaryBytes(stakeStore.Get(stake.ParamKey), &stakeParams))
+	assert.Equal(uint16(2), stakeParams.MaxVals)
+	assert.Equal(int64(5), stakeParams.GasDelegate)
+
+	records := loadParamChangeRecords(govStore)
+	require.Equal(2, len(records))
+	assert.Equal(ParamChangeRecord{1, applyHeight, "max_vals", "2"}, records[0])
+	assert.Equal(ParamChangeRecord{1, applyHeight, "gas_bond", "5"}, records[1])
+
+	// the changes are validated again when applied, a proposal with a change
+	// rejected applies none of its changes and the chain goes on
+	rejected := loadProposal(govStore, 1)
+	rejected.ProposalID = 2
+	rejected.ParamChanges = []ParamChange{{"max_vals", "3"}, {"epoch_length", "0"}}
+	saveProposal(govStore, rejected)
+	savePendingParamChanges(govStore, []int64{2})
+	require.NoError(EndBlock(stack.NewContext("testChain", applyHeight+1, log.NewNopLogger()), store))
+	assert.Zero(len(loadPendingParamChanges(govStore)))
+	assert.Equal(uint16(2), stake.NewView(stakeStore).Params().MaxVals)
+	assert.Equal(2, len(loadParamChangeRecords(govStore)))
+}
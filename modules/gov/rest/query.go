@@ -0,0 +1,226 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/spf13/viper"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/commands"
+	"github.com/cosmos/cosmos-sdk/client/commands/query"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+
+	"github.com/cosmos/gaia/modules/gov"
+
+	"github.com/tendermint/tmlibs/common"
+)
+
+// RegisterQueryProposal is a mux.Router handler that exposes GET
+// method access on route /query/gov/proposal/{id} to query a proposal
+func RegisterQueryProposal(r *mux.Router) error {
+	r.HandleFunc("/query/gov/proposal/{id}", queryProposal).Methods("GET")
+	return nil
+}
+
+// RegisterQueryActiveProposals is a mux.Router handler that exposes GET
+// method access on route /query/gov/active_proposals to query the ids of
+// all the proposals in their deposit or voting period
+func RegisterQueryActiveProposals(r *mux.Router) error {
+	r.HandleFunc("/query/gov/active_proposals", queryActiveProposals).Methods("GET")
+	return nil
+}
+
+// RegisterQueryDeposit is a mux.Router handler that exposes GET
+// method access on route /query/gov/deposit/{id}/{address} to query
+// the deposit of an account to a proposal
+func RegisterQueryDeposit(r *mux.Router) error {
+	r.HandleFunc("/query/gov/deposit/{id}/{address}", queryDeposit).Methods("GET")
+	return nil
+}
+
+// RegisterQueryVote is a mux.Router handler that exposes GET
+// method access on route /query/gov/vote/{id}/{address} to query
+// the vote of an account on a proposal
+func RegisterQueryVote(r *mux.Router) error {
+	r.HandleFunc("/query/gov/vote/{id}/{address}", queryVote).Methods("GET")
+	return nil
+}
+
+// RegisterQueryParamChanges is a mux.Router handler that exposes GET
+// method access on route /query/gov/param_changes to query the record
+// of all the parameter changes applied by governance
+func RegisterQueryParamChanges(r *mux.Router) error {
+	r.HandleFunc("/query/gov/param_changes", queryParamChanges).Methods("GET")
+	return nil
+}
+
+//---------------------------------------------------------------------
+
+// queryProposal is the HTTP handlerfunc to query a proposal
+func queryProposal(w http.ResponseWriter, r *http.Request) {
+
+	// get the arguments object
+	args := mux.Vars(r)
+	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
+
+	proposalID, err := parseProposalID(args["id"])
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// get the proposal
+	var proposal gov.Proposal
+	key := stack.PrefixedKey(gov.Name(), gov.GetProposalKey(proposalID))
+	height, err := query.GetParsed(key, &proposal, query.GetHeight(), prove)
+	if client.IsNoDataErr(err) {
+		err := fmt.Errorf("proposal bytes are empty for id: %d", proposalID)
+		common.WriteError(w, err)
+		return
+	} else if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// write the output
+	err = query.FoutputProof(w, proposal, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryActiveProposals is the HTTP handlerfunc to query the ids of the
+// active proposals
+func queryActiveProposals(w http.ResponseWriter, r *http.Request) {
+
+	var proposalIDs []int64
+
+	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
+	key := stack.PrefixedKey(gov.Name(), gov.ActiveProposalsKey)
+	height, err := query.GetParsed(key, &proposalIDs, query.GetHeight(), prove)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	err = query.FoutputProof(w, proposalIDs, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryDeposit is the HTTP handlerfunc to query the deposit of an account
+func queryDeposit(w http.ResponseWriter, r *http.Request) {
+
+	// get the arguments object
+	args := mux.Vars(r)
+	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
+
+	proposalID, err := parseProposalID(args["id"])
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	depositor, err := parseAddress(args["address"])
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// get the deposit
+	var deposit gov.Deposit
+	key := stack.PrefixedKey(gov.Name(), gov.GetDepositKey(proposalID, depositor))
+	height, err := query.GetParsed(key, &deposit, query.GetHeight(), prove)
+	if client.IsNoDataErr(err) {
+		err := fmt.Errorf("deposit bytes are empty for id: %d, address: %q", proposalID, args["address"])
+		common.WriteError(w, err)
+		return
+	} else if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// write the output
+	err = query.FoutputProof(w, deposit, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryVote is the HTTP handlerfunc to query the vote of an account
+func queryVote(w http.ResponseWriter, r *http.Request) {
+
+	// get the arguments object
+	args := mux.Vars(r)
+	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
+
+	proposalID, err := parseProposalID(args["id"])
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	voter, err := parseAddress(args["address"])
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// get the vote
+	var vote gov.Vote
+	key := stack.PrefixedKey(gov.Name(), gov.GetVoteKey(proposalID, voter))
+	height, err := query.GetParsed(key, &vote, query.GetHeight(), prove)
+	if client.IsNoDataErr(err) {
+		err := fmt.Errorf("vote bytes are empty for id: %d, address: %q", proposalID, args["address"])
+		common.WriteError(w, err)
+		return
+	} else if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// write the output
+	err = query.FoutputProof(w, vote, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryParamChanges is the HTTP handlerfunc to query the applied parameter changes
+func queryParamChanges(w http.ResponseWriter, r *http.Request) {
+
+	var records []gov.ParamChangeRecord
+
+	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
+	key := stack.PrefixedKey(gov.Name(), gov.ParamChangesKey)
+	height, err := query.GetParsed(key, &records, query.GetHeight(), prove)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	err = query.FoutputProof(w, records, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+func parseProposalID(idArg string) (int64, error) {
+	proposalID, err := strconv.ParseInt(idArg, 10, 64)
+	if err != nil || proposalID <= 0 {
+		return 0, fmt.Errorf("proposal id must be a positive integer, got %q", idArg)
+	}
+	return proposalID, nil
+}
+
+func parseAddress(addr string) (actor sdk.Actor, err error) {
+	actor, err = commands.ParseActor(addr)
+	if err != nil {
+		return
+	}
+	return coin.ChainAddr(actor), nil
+}
@@ -0,0 +1,270 @@
+package gov
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/tendermint/go-wire"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// nolint
+var (
+	// Keys for store prefixes
+	ParamKey               = []byte{0x01} // key for global parameters relating to governance
+	LastProposalIDKey      = []byte{0x02} // key for the id of the last submitted proposal
+	ActiveProposalsKey     = []byte{0x03} // key for the ids of all proposals in their deposit or voting period
+	ProposalKeyPrefix      = []byte{0x04} // prefix for each key to a proposal
+	DepositKeyPrefix       = []byte{0x05} // prefix for each key to a proposal deposit
+	DepositorsKeyPrefix    = []byte{0x06} // prefix for each key to the list of a proposal's depositors
+	VoteKeyPrefix          = []byte{0x07} // prefix for each key to a proposal vote
+	VotersKeyPrefix        = []byte{0x08} // prefix for each key to the list of a proposal's voters
+	ParamChangesKey        = []byte{0x09} // key for the record of all applied parameter changes
+	PendingParamChangesKey = []byte{0x0A} // key for the ids of passed proposals with changes still to apply
+	StakeParamsKey         = []byte{0x0B} // key for the copy of the current staking parameters
+)
+
+func proposalIDBytes(proposalID int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(proposalID))
+	return b
+}
+
+// GetProposalKey - get the key for the proposal with proposalID
+func GetProposalKey(proposalID int64) []byte {
+	return append(ProposalKeyPrefix, proposalIDBytes(proposalID)...)
+}
+
+// GetDepositKey - get the key for the deposit of depositor to a proposal
+func GetDepositKey(proposalID int64, depositor sdk.Actor) []byte {
+	key := append(DepositKeyPrefix, proposalIDBytes(proposalID)...)
+	return append(key, wire.BinaryBytes(&depositor)...)
+}
+
+// GetDepositorsKey - get the key for the list of all the depositors of a proposal
+func GetDepositorsKey(proposalID int64) []byte {
+	return append(DepositorsKeyPrefix, proposalIDBytes(proposalID)...)
+}
+
+// GetVoteKey - get the key for the vote of voter on a proposal
+func GetVoteKey(proposalID int64, voter sdk.Actor) []byte {
+	key := append(VoteKeyPrefix, proposalIDBytes(proposalID)...)
+	return append(key, wire.BinaryBytes(&voter)...)
+}
+
+// GetVotersKey - get the key for the list of all the voters of a proposal
+func GetVotersKey(proposalID int64) []byte {
+	return append(VotersKeyPrefix, proposalIDBytes(proposalID)...)
+}
+
+//---------------------------------------------------------------------
+
+// get and increment the id of the last submitted proposal
+func nextProposalID(store state.SimpleDB) int64 {
+	var lastID int64
+	b := store.Get(LastProposalIDKey)
+	if b != nil {
+		err := wire.ReadBinaryBytes(b, &lastID)
+		if err != nil {
+			panic(err)
+		}
+	}
+	lastID++
+	store.Set(LastProposalIDKey, wire.BinaryBytes(lastID))
+	return lastID
+}
+
+func loadProposalIDs(store state.SimpleDB, key []byte) (proposalIDs []int64) {
+	b := store.Get(key)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &proposalIDs)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// load/save the ids of the proposals in their deposit or voting period
+func loadActiveProposalIDs(store state.SimpleDB) []int64 {
+	return loadProposalIDs(store, ActiveProposalsKey)
+}
+func saveActiveProposalIDs(store state.SimpleDB, proposalIDs []int64) {
+	store.Set(ActiveProposalsKey, wire.BinaryBytes(proposalIDs))
+}
+
+// load/save the ids of the passed proposals with parameter changes to apply
+func loadPendingParamChanges(store state.SimpleDB) []int64 {
+	return loadProposalIDs(store, PendingParamChangesKey)
+}
+func savePendingParamChanges(store state.SimpleDB, proposalIDs []int64) {
+	store.Set(PendingParamChangesKey, wire.BinaryBytes(proposalIDs))
+}
+
+//---------------------------------------------------------------------
+
+// loadProposal - loads the proposal object for the provided id
+func loadProposal(store state.SimpleDB, proposalID int64) *Proposal {
+	b := store.Get(GetProposalKey(proposalID))
+	if b == nil {
+		return nil
+	}
+	proposal := new(Proposal)
+	err := wire.ReadBinaryBytes(b, proposal)
+	if err != nil {
+		panic(err) // This error should never occure big problem if does
+	}
+	return proposal
+}
+
+func saveProposal(store state.SimpleDB, proposal *Proposal) {
+	b := wire.BinaryBytes(*proposal)
+	store.Set(GetProposalKey(proposal.ProposalID), b)
+}
+
+//---------------------------------------------------------------------
+
+func loadActors(store state.SimpleDB, key []byte) (actors []sdk.Actor) {
+	b := store.Get(key)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &actors)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// load the actors of all depositors to a proposal
+func loadDepositors(store state.SimpleDB, proposalID int64) []sdk.Actor {
+	return loadActors(store, GetDepositorsKey(proposalID))
+}
+
+func loadDeposit(store state.SimpleDB, proposalID int64, depositor sdk.Actor) *Deposit {
+	b := store.Get(GetDepositKey(proposalID, depositor))
+	if b == nil {
+		return nil
+	}
+	deposit := new(Deposit)
+	err := wire.ReadBinaryBytes(b, deposit)
+	if err != nil {
+		panic(err)
+	}
+	return deposit
+}
+
+func saveDeposit(store state.SimpleDB, proposalID int64, deposit *Deposit) {
+
+	// if a new deposit add to the list of depositors
+	if !store.Has(GetDepositKey(proposalID, deposit.Depositor)) {
+		depositors := loadDepositors(store, proposalID)
+		depositors = append(depositors, deposit.Depositor)
+		store.Set(GetDepositorsKey(proposalID), wire.BinaryBytes(depositors))
+	}
+
+	b := wire.BinaryBytes(*deposit)
+	store.Set(GetDepositKey(proposalID, deposit.Depositor), b)
+}
+
+//---------------------------------------------------------------------
+
+// load the actors of all voters on a proposal
+func loadVoters(store state.SimpleDB, proposalID int64) []sdk.Actor {
+	return loadActors(store, GetVotersKey(proposalID))
+}
+
+func loadVote(store state.SimpleDB, proposalID int64, voter sdk.Actor) *Vote {
+	b := store.Get(GetVoteKey(proposalID, voter))
+	if b == nil {
+		return nil
+	}
+	vote := new(Vote)
+	err := wire.ReadBinaryBytes(b, vote)
+	if err != nil {
+		panic(err)
+	}
+	return vote
+}
+
+func saveVote(store state.SimpleDB, proposalID int64, vote *Vote) {
+
+	// if a new vote add to the list of voters
+	if !store.Has(GetVoteKey(proposalID, vote.Voter)) {
+		voters := loadVoters(store, proposalID)
+		voters = append(voters, vote.Voter)
+		store.Set(GetVotersKey(proposalID), wire.BinaryBytes(voters))
+	}
+
+	b := wire.BinaryBytes(*vote)
+	store.Set(GetVoteKey(proposalID, vote.Voter), b)
+}
+
+//---------------------------------------------------------------------
+
+// load the record of all the applied parameter changes
+func loadParamChangeRecords(store state.SimpleDB) (records []ParamChangeRecord) {
+	b := store.Get(ParamChangesKey)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &records)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func recordParamChange(store state.SimpleDB, record ParamChangeRecord) {
+	records := append(loadParamChangeRecords(store), record)
+	store.Set(ParamChangesKey, wire.BinaryBytes(records))
+}
+
+//---------------------------------------------------------------------
+
+// load/save the global governance params
+func loadParams(store state.SimpleDB) (params Params) {
+	b := store.Get(ParamKey)
+	if b == nil {
+		return defaultParams()
+	}
+
+	err := wire.ReadBinaryBytes(b, &params)
+	if err != nil {
+		panic(err) // This error should never occure big problem if does
+	}
+
+	return
+}
+func saveParams(store state.SimpleDB, params Params) {
+	b := wire.BinaryBytes(params)
+	store.Set(ParamKey, b)
+}
+
+// load the copy of the staking parameters, the parameters of a new stake
+// store until the first block
+func loadStakeParams(store state.SimpleDB) (params stake.Params) {
+	b := store.Get(StakeParamsKey)
+	if b == nil {
+		return stake.NewView(state.NewMemKVStore()).Params()
+	}
+
+	err := wire.ReadBinaryBytes(b, &params)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// save the copy of the staking parameters, only when they changed
+func saveStakeParams(store state.SimpleDB, params stake.Params) {
+	b := wire.BinaryBytes(params)
+	if !bytes.Equal(store.Get(StakeParamsKey), b) {
+		store.Set(StakeParamsKey, b)
+	}
+}
@@ -0,0 +1,61 @@
+package gov
+
+import (
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// candidateTally - the shares of a candidate which have not been voted by
+// delegators directly, and which follow the vote of the candidate owner
+type candidateTally struct {
+	candidate *stake.Candidate
+	inherited uint64
+	vote      *VoteOption
+}
+
+// tally - count the bonded shares behind each vote option of a proposal.
+// Every bonded share counts once: delegators vote with their own bonds, and
+// the shares of delegators who did not vote follow the vote of the candidate
+// owner they are bonded to.
+func tally(store, stakeStore state.SimpleDB, proposalID int64) (res TallyResult) {
+
+	view := stake.NewView(stakeStore)
+	candidates := make(map[string]*candidateTally)
+	view.IterateCandidates(func(candidate *stake.Candidate) bool {
+		candidates[string(candidate.PubKey.Bytes())] = &candidateTally{
+			candidate: candidate,
+			inherited: candidate.Shares,
+		}
+		res.TotalShares += candidate.Shares
+		return false
+	})
+
+	for _, voter := range loadVoters(store, proposalID) {
+		vote := loadVote(store, proposalID, voter)
+		for _, bond := range view.DelegatorBonds(voter) {
+			c, ok := candidates[string(bond.PubKey.Bytes())]
+			if !ok {
+				continue
+			}
+
+			// the owner's vote is applied to all the inherited shares below
+			if voter.Equals(c.candidate.Owner) {
+				option := vote.Option
+				c.vote = &option
+				continue
+			}
+
+			// a delegator vote overrides the vote of the candidate owner
+			res.add(vote.Option, bond.Shares)
+			c.inherited -= bond.Shares
+		}
+	}
+
+	for _, c := range candidates {
+		if c.vote != nil {
+			res.add(*c.vote, c.inherited)
+		}
+	}
+	return
+}
@@ -0,0 +1,117 @@
+package gov
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	crypto "github.com/tendermint/go-crypto"
+	"github.com/tendermint/go-wire"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+func newPubKey(pk string) crypto.PubKey {
+	pkBytes, _ := hex.DecodeString(pk)
+	var pkEd crypto.PubKeyEd25519
+	copy(pkEd[:], pkBytes[:])
+	return pkEd.Wrap()
+}
+
+var pks = []crypto.PubKey{
+	newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB51"),
+	newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB52"),
+	newPubKey("0B485CFC0EECC619440448436F8FC9DF40566F2369E72400281454CB552AFB53"),
+}
+
+// write the candidate and bonds through the stake key layout
+func saveTestCandidate(stakeStore state.SimpleDB, pk crypto.PubKey, owner sdk.Actor, shares uint64) {
+	var pubKeys []crypto.PubKey
+	if b := stakeStore.Get(stake.CandidatesPubKeysKey); b != nil {
+		wire.ReadBinaryBytes(b, &pubKeys)
+	}
+	stakeStore.Set(stake.CandidatesPubKeysKey, wire.BinaryBytes(append(pubKeys, pk)))
+
+	candidate := stake.Candidate{PubKey: pk, Owner: owner, Shares: shares, VotingPower: shares}
+	stakeStore.Set(stake.GetCandidateKey(pk), wire.BinaryBytes(candidate))
+}
+
+func saveTestBond(stakeStore state.SimpleDB, delegator sdk.Actor, pk crypto.PubKey, shares uint64) {
+	var pubKeys []crypto.PubKey
+	if b := stakeStore.Get(stake.GetDelegatorBondsKey(delegator)); b != nil {
+		wire.ReadBinaryBytes(b, &pubKeys)
+	}
+	stakeStore.Set(stake.GetDelegatorBondsKey(delegator), wire.BinaryBytes(append(pubKeys, pk)))
+
+	bond := stake.DelegatorBond{PubKey: pk, Shares: shares}
+	stakeStore.Set(stake.GetDelegatorBondKey(delegator, pk), wire.BinaryBytes(bond))
+}
+
+func TestTally(t *testing.T) {
+	assert := assert.New(t)
+	actors := newActors(5)
+	store, stakeStore := state.NewMemKVStore(), state.NewMemKVStore()
+
+	// three candidates with self bonds, two delegators on the first
+	for i := 0; i < 3; i++ {
+		saveTestBond(stakeStore, actors[i], pks[i], 10)
+	}
+	saveTestCandidate(stakeStore, pks[0], actors[0], 40)
+	saveTestCandidate(stakeStore, pks[1], actors[1], 10)
+	saveTestCandidate(stakeStore, pks[2], actors[2], 10)
+	saveTestBond(stakeStore, actors[3], pks[0], 20)
+	saveTestBond(stakeStore, actors[4], pks[0], 10)
+
+	// nobody voted
+	res := tally(store, stakeStore, 1)
+	assert.Equal(TallyResult{TotalShares: 60}, res)
+
+	// the owner votes for all the shares of its candidate
+	saveVote(store, 1, &Vote{actors[0], OptionYes})
+	res = tally(store, stakeStore, 1)
+	assert.Equal(TallyResult{Yes: 40, TotalShares: 60}, res)
+
+	// a delegator overrides the owner's vote with its own shares
+	saveVote(store, 1, &Vote{actors[3], OptionNoWithVeto})
+	saveVote(store, 1, &Vote{actors[1], OptionAbstain})
+	res = tally(store, stakeStore, 1)
+	assert.Equal(TallyResult{Yes: 20, Abstain: 10, NoWithVeto: 20, TotalShares: 60}, res)
+
+	// only a delegator votes, the other shares of the candidate do not count
+	saveVote(store, 2, &Vote{actors[4], OptionNo})
+	res = tally(store, stakeStore, 2)
+	assert.Equal(TallyResult{No: 10, TotalShares: 60}, res)
+}
+
+func TestTallyResultPasses(t *testing.T) {
+	assert := assert.New(t)
+	params := defaultParams()
+
+	cases := []struct {
+		res            TallyResult
+		passes, vetoed bool
+	}{
+		{TallyResult{TotalShares: 100}, false, false},
+		// no quorum
+		{TallyResult{Yes: 32, TotalShares: 100}, false, false},
+		{TallyResult{Yes: 33, TotalShares: 100}, true, false},
+		// abstain counts for the quorum only
+		{TallyResult{Yes: 1, Abstain: 40, TotalShares: 100}, true, false},
+		{TallyResult{Abstain: 40, TotalShares: 100}, false, false},
+		// the threshold is strictly greater than half
+		{TallyResult{Yes: 30, No: 30, TotalShares: 100}, false, false},
+		{TallyResult{Yes: 31, No: 30, TotalShares: 100}, true, false},
+		// a veto rejects the proposal
+		{TallyResult{Yes: 60, NoWithVeto: 30, TotalShares: 100}, false, true},
+		{TallyResult{Yes: 60, NoWithVeto: 20, TotalShares: 100}, true, false},
+	}
+
+	for i, tc := range cases {
+		passes, vetoed := tc.res.passes(params)
+		assert.Equal(tc.passes, passes, "%d", i)
+		assert.Equal(tc.vetoed, vetoed, "%d", i)
+	}
+}
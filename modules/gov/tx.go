@@ -0,0 +1,167 @@
+package gov
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+)
+
+// Tx
+//--------------------------------------------------------------------------------
+
+// register the tx type with its validation logic
+// make sure to use the name of the handler as the prefix in the tx type,
+// so it gets routed properly
+const (
+	ByteTxSubmitProposal            = 0x70
+	ByteTxDeposit                   = 0x71
+	ByteTxVote                      = 0x72
+	ByteTxSubmitParamChangeProposal = 0x73
+	TypeTxSubmitProposal            = govModuleName + "/submitProposal"
+	TypeTxDeposit                   = govModuleName + "/deposit"
+	TypeTxVote                      = govModuleName + "/vote"
+	TypeTxSubmitParamChangeProposal = govModuleName + "/submitParamChangeProposal"
+)
+
+func init() {
+	sdk.TxMapper.RegisterImplementation(TxSubmitProposal{}, TypeTxSubmitProposal, ByteTxSubmitProposal)
+	sdk.TxMapper.RegisterImplementation(TxDeposit{}, TypeTxDeposit, ByteTxDeposit)
+	sdk.TxMapper.RegisterImplementation(TxVote{}, TypeTxVote, ByteTxVote)
+	sdk.TxMapper.RegisterImplementation(TxSubmitParamChangeProposal{}, TypeTxSubmitParamChangeProposal, ByteTxSubmitParamChangeProposal)
+}
+
+// Verify interface at compile time
+var _, _, _, _ sdk.TxInner = &TxSubmitProposal{}, &TxDeposit{}, &TxVote{}, &TxSubmitParamChangeProposal{}
+
+// TxSubmitProposal - struct for submitting a new proposal
+type TxSubmitProposal struct {
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	InitialDeposit coin.Coin `json:"initial_deposit"`
+}
+
+// NewTxSubmitProposal - new TxSubmitProposal
+func NewTxSubmitProposal(title, description string, initialDeposit coin.Coin) sdk.Tx {
+	return TxSubmitProposal{
+		Title:          title,
+		Description:    description,
+		InitialDeposit: initialDeposit,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxSubmitProposal) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for a title and a non-negative deposit
+func (tx TxSubmitProposal) ValidateBasic() error {
+	if len(tx.Title) == 0 {
+		return errEmptyTitle
+	}
+	if tx.InitialDeposit.Amount < 0 {
+		return coin.ErrInvalidCoins()
+	}
+	return nil
+}
+
+// TxSubmitParamChangeProposal - struct for submitting a proposal to change
+// staking parameters at a scheduled height
+type TxSubmitParamChangeProposal struct {
+	TxSubmitProposal
+	ParamChanges []ParamChange `json:"param_changes"`
+	ApplyHeight  int64         `json:"apply_height"`
+}
+
+// NewTxSubmitParamChangeProposal - new TxSubmitParamChangeProposal
+func NewTxSubmitParamChangeProposal(title, description string, initialDeposit coin.Coin,
+	paramChanges []ParamChange, applyHeight int64) sdk.Tx {
+
+	return TxSubmitParamChangeProposal{
+		TxSubmitProposal: TxSubmitProposal{
+			Title:          title,
+			Description:    description,
+			InitialDeposit: initialDeposit,
+		},
+		ParamChanges: paramChanges,
+		ApplyHeight:  applyHeight,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxSubmitParamChangeProposal) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check the proposal, the changes are validated on the
+// current staking parameters by the handler
+func (tx TxSubmitParamChangeProposal) ValidateBasic() error {
+	err := tx.TxSubmitProposal.ValidateBasic()
+	if err != nil {
+		return err
+	}
+	if len(tx.ParamChanges) == 0 {
+		return errNoParamChanges
+	}
+	if tx.ApplyHeight <= 0 {
+		return errBadApplyHeight
+	}
+	return nil
+}
+
+// TxDeposit - struct for adding a deposit to an active proposal
+type TxDeposit struct {
+	ProposalID int64     `json:"proposal_id"`
+	Amount     coin.Coin `json:"amount"`
+}
+
+// NewTxDeposit - new TxDeposit
+func NewTxDeposit(proposalID int64, amount coin.Coin) sdk.Tx {
+	return TxDeposit{
+		ProposalID: proposalID,
+		Amount:     amount,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxDeposit) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for a valid proposal id and positive coins
+func (tx TxDeposit) ValidateBasic() error {
+	if tx.ProposalID <= 0 {
+		return errBadProposalID
+	}
+	coins := coin.Coins{tx.Amount}
+	if !coins.IsValid() {
+		return coin.ErrInvalidCoins()
+	}
+	if !coins.IsPositive() {
+		return fmt.Errorf("Amount must be > 0")
+	}
+	return nil
+}
+
+// TxVote - struct for voting on a proposal in its voting period
+type TxVote struct {
+	ProposalID int64      `json:"proposal_id"`
+	Option     VoteOption `json:"option"`
+}
+
+// NewTxVote - new TxVote
+func NewTxVote(proposalID int64, option VoteOption) sdk.Tx {
+	return TxVote{
+		ProposalID: proposalID,
+		Option:     option,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxVote) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for a valid proposal id and vote option
+func (tx TxVote) ValidateBasic() error {
+	if tx.ProposalID <= 0 {
+		return errBadProposalID
+	}
+	if !tx.Option.isValid() {
+		return errBadVoteOption
+	}
+	return nil
+}
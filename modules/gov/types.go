@@ -0,0 +1,215 @@
+package gov
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+)
+
+// Params defines the high level settings for governance
+type Params struct {
+	HoldAccount sdk.Actor `json:"hold_account"` // Actor where all deposits are held
+	BondDenom   string    `json:"bond_denom"`   // coin denomination of deposits, the bond denomination of stake
+
+	MinDeposit       int64 `json:"min_deposit"`        // deposit required to enter the voting period
+	MaxDepositPeriod int64 `json:"max_deposit_period"` // blocks to reach the min deposit
+	VotingPeriod     int64 `json:"voting_period"`      // blocks during which votes are accepted
+
+	// tally parameters in percent
+	Quorum    int64 `json:"quorum"`    // minimum share of the bonded shares which must vote
+	Threshold int64 `json:"threshold"` // minimum share of the non-abstaining votes to pass
+	Veto      int64 `json:"veto"`      // minimum share of the votes to veto
+
+	// gas costs for txs
+	GasSubmitProposal int64 `json:"gas_submit_proposal"`
+	GasDeposit        int64 `json:"gas_deposit"`
+	GasVote           int64 `json:"gas_vote"`
+}
+
+func defaultParams() Params {
+	return Params{
+		HoldAccount:       sdk.NewActor(govModuleName, []byte("88888888888888888888888888888888")),
+		BondDenom:         "fermion",
+		MinDeposit:        100,
+		MaxDepositPeriod:  1000,
+		VotingPeriod:      1000,
+		Quorum:            33,
+		Threshold:         50,
+		Veto:              33,
+		GasSubmitProposal: 20,
+		GasDeposit:        20,
+		GasVote:           20,
+	}
+}
+
+//_________________________________________________________________________
+
+// ProposalStatus - the stage of the lifecycle a proposal is in
+type ProposalStatus byte
+
+// nolint
+const (
+	StatusDepositPeriod ProposalStatus = 0x01
+	StatusVotingPeriod  ProposalStatus = 0x02
+	StatusPassed        ProposalStatus = 0x03
+	StatusRejected      ProposalStatus = 0x04
+)
+
+// String - human readable proposal status
+func (s ProposalStatus) String() string {
+	switch s {
+	case StatusDepositPeriod:
+		return "DepositPeriod"
+	case StatusVotingPeriod:
+		return "VotingPeriod"
+	case StatusPassed:
+		return "Passed"
+	case StatusRejected:
+		return "Rejected"
+	}
+	return fmt.Sprintf("Unknown(%d)", s)
+}
+
+// Proposal - a governance proposal and the result of its tally
+type Proposal struct {
+	ProposalID  int64          `json:"proposal_id"`
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Proposer    sdk.Actor      `json:"proposer"`
+	Status      ProposalStatus `json:"status"`
+
+	SubmitHeight      int64     `json:"submit_height"`       // height the proposal was submitted
+	VotingStartHeight int64     `json:"voting_start_height"` // height the min deposit was reached
+	TotalDeposit      coin.Coin `json:"total_deposit"`       // sum of all the deposits
+
+	TallyResult TallyResult `json:"tally_result"` // set once the voting period has ended
+
+	// staking parameter changes applied at ApplyHeight if the proposal passes
+	ParamChanges []ParamChange `json:"param_changes"`
+	ApplyHeight  int64         `json:"apply_height"`
+}
+
+// isActive - the proposal is still accepting deposits or votes
+func (p Proposal) isActive() bool {
+	return p.Status == StatusDepositPeriod || p.Status == StatusVotingPeriod
+}
+
+// Deposit - coins deposited by a single depositor to a proposal
+type Deposit struct {
+	Depositor sdk.Actor `json:"depositor"`
+	Amount    coin.Coin `json:"amount"`
+}
+
+// ParamChange - a new value for a staking parameter, keyed as in the genesis
+type ParamChange struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ParamChangeRecord - a parameter change applied after its proposal passed
+type ParamChangeRecord struct {
+	ProposalID int64  `json:"proposal_id"`
+	Height     int64  `json:"height"`
+	Key        string `json:"key"`
+	Value      string `json:"value"`
+}
+
+//_________________________________________________________________________
+
+// VoteOption - the choice of a voter on a proposal
+type VoteOption byte
+
+// nolint
+const (
+	OptionYes        VoteOption = 0x01
+	OptionAbstain    VoteOption = 0x02
+	OptionNo         VoteOption = 0x03
+	OptionNoWithVeto VoteOption = 0x04
+)
+
+// ParseVoteOption - parse a vote option from its command line form
+func ParseVoteOption(str string) (VoteOption, error) {
+	switch strings.ToLower(str) {
+	case "yes":
+		return OptionYes, nil
+	case "abstain":
+		return OptionAbstain, nil
+	case "no":
+		return OptionNo, nil
+	case "veto", "no_with_veto":
+		return OptionNoWithVeto, nil
+	}
+	return 0, fmt.Errorf("invalid vote option %q, must be one of yes, no, abstain or veto", str)
+}
+
+// String - human readable vote option
+func (o VoteOption) String() string {
+	switch o {
+	case OptionYes:
+		return "Yes"
+	case OptionAbstain:
+		return "Abstain"
+	case OptionNo:
+		return "No"
+	case OptionNoWithVeto:
+		return "NoWithVeto"
+	}
+	return fmt.Sprintf("Unknown(%d)", o)
+}
+
+func (o VoteOption) isValid() bool {
+	return o >= OptionYes && o <= OptionNoWithVeto
+}
+
+// Vote - the vote of a single voter on a proposal
+type Vote struct {
+	Voter  sdk.Actor  `json:"voter"`
+	Option VoteOption `json:"option"`
+}
+
+// TallyResult - bonded shares backing each vote option
+type TallyResult struct {
+	Yes        uint64 `json:"yes"`
+	Abstain    uint64 `json:"abstain"`
+	No         uint64 `json:"no"`
+	NoWithVeto uint64 `json:"no_with_veto"`
+
+	TotalShares uint64 `json:"total_shares"` // all bonded shares at the end of the vote
+}
+
+func (t *TallyResult) add(option VoteOption, shares uint64) {
+	switch option {
+	case OptionYes:
+		t.Yes += shares
+	case OptionAbstain:
+		t.Abstain += shares
+	case OptionNo:
+		t.No += shares
+	case OptionNoWithVeto:
+		t.NoWithVeto += shares
+	}
+}
+
+// voted - total shares which took part in the vote
+func (t TallyResult) voted() uint64 {
+	return t.Yes + t.Abstain + t.No + t.NoWithVeto
+}
+
+// passes - determine the outcome of a tally, vetoed is true if the veto
+// threshold was reached, in which case the deposits are not refunded
+func (t TallyResult) passes(params Params) (passes, vetoed bool) {
+	voted := t.voted()
+	if voted == 0 || voted*100 < uint64(params.Quorum)*t.TotalShares {
+		return false, false
+	}
+	if t.NoWithVeto*100 > uint64(params.Veto)*voted {
+		return false, true
+	}
+	nonAbstain := voted - t.Abstain
+	if nonAbstain == 0 {
+		return false, false
+	}
+	return t.Yes*100 > uint64(params.Threshold)*nonAbstain, false
+}
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	crypto "github.com/tendermint/go-crypto"
+	wire "github.com/tendermint/go-wire"
+	"github.com/tendermint/tendermint/lite"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/modules/nonce"
+	"github.com/cosmos/cosmos-sdk/stack"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// Client - typed queries of the stake state of a node, and builders of the
+// stake txs it broadcasts. The queries return the height of the state they read, and
+// fail with an error matching client.IsNoDataErr for a missing object.
+type Client struct {
+	chainID string
+	node    rpcclient.Client
+	cert    lite.Certifier
+	height  int64
+}
+
+// New - a client of the node of a chain. The state read from the node is
+// proven with the certifier, a nil certifier trusts the node.
+func New(chainID string, node rpcclient.Client, cert lite.Certifier) Client {
+	return Client{
+		chainID: chainID,
+		node:    node,
+		cert:    cert,
+	}
+}
+
+// WithHeight - a client querying the state at a height, 0 for the latest
+func (c Client) WithHeight(height int64) Client {
+	c.height = height
+	return c
+}
+
+// ChainID - the chain of the txs built by the client
+func (c Client) ChainID() string {
+	return c.chainID
+}
+
+// get - read a key of the stake store into data
+func (c Client) get(key []byte, data interface{}) (height int64, err error) {
+	return c.read(stack.PrefixedKey(stake.Name(), key), data)
+}
+
+// read - read a key of the global store into data
+func (c Client) read(key []byte, data interface{}) (height int64, err error) {
+	var val []byte
+	if c.cert != nil {
+		val, height, _, err = client.GetWithProof(key, c.height, c.node, c.cert)
+		if err != nil {
+			return height, err
+		}
+	} else {
+		resp, err := c.node.ABCIQueryWithOptions("/key", key,
+			rpcclient.ABCIQueryOptions{Trusted: true, Height: c.height})
+		if err != nil {
+			return 0, err
+		}
+		val, height = resp.Response.Value, resp.Response.Height
+		if len(val) == 0 {
+			return height, client.ErrNoData()
+		}
+	}
+	return height, wire.ReadBinaryBytes(val, data)
+}
+
+// getList - read a key holding a list, a missing list is empty
+func (c Client) getList(key []byte, data interface{}) (height int64, err error) {
+	height, err = c.get(key, data)
+	if client.IsNoDataErr(err) {
+		return height, nil
+	}
+	return height, err
+}
+
+// Params - the params of the stake module
+func (c Client) Params() (params stake.Params, height int64, err error) {
+	height, err = c.get(stake.ParamKey, &params)
+	return
+}
+
+// Epoch - the current epoch
+func (c Client) Epoch() (epoch stake.Epoch, height int64, err error) {
+	height, err = c.get(stake.EpochKey, &epoch)
+	return
+}
+
+// CandidatesPubKeys - the pubkeys of all the candidates, in the order of
+// their declaration
+func (c Client) CandidatesPubKeys() (pubKeys []crypto.PubKey, height int64, err error) {
+	height, err = c.getList(stake.CandidatesPubKeysKey, &pubKeys)
+	return
+}
+
+// Candidate - the candidate of a pubkey
+func (c Client) Candidate(pubKey crypto.PubKey) (*stake.Candidate, int64, error) {
+	candidate := new(stake.Candidate)
+	height, err := c.get(stake.GetCandidateKey(pubKey), candidate)
+	if err != nil {
+		return nil, height, err
+	}
+	if len(candidate.OwnerChanges) == 0 {
+		candidate.OwnerChanges = nil // decoded as an empty slice
+	}
+	return candidate, height, nil
+}
+
+// Candidates - all the candidates, in the order of their declaration, read
+// at the same height
+func (c Client) Candidates() (candidates stake.Candidates, height int64, err error) {
+	pubKeys, height, err := c.CandidatesPubKeys()
+	if err != nil {
+		return nil, height, err
+	}
+	at := c.WithHeight(height)
+	for _, pk := range pubKeys {
+		candidate, _, err := at.Candidate(pk)
+		if err != nil {
+			return nil, height, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, height, nil
+}
+
+// Bond - the bond of a delegator to a candidate
+func (c Client) Bond(delegator sdk.Actor, pubKey crypto.PubKey) (*stake.DelegatorBond, int64, error) {
+	bond := new(stake.DelegatorBond)
+	height, err := c.get(stake.GetDelegatorBondKey(delegator, pubKey), bond)
+	if err != nil {
+		return nil, height, err
+	}
+	return bond, height, nil
+}
+
+// DelegatorCandidates - the pubkeys of the candidates a delegator is bonded
+// to
+func (c Client) DelegatorCandidates(delegator sdk.Actor) (pubKeys []crypto.PubKey, height int64, err error) {
+	height, err = c.getList(stake.GetDelegatorBondsKey(delegator), &pubKeys)
+	return
+}
+
+// Delegations - all the bonds of a delegator, read at the same height
+func (c Client) Delegations(delegator sdk.Actor) (bonds []stake.DelegatorBond, height int64, err error) {
+	pubKeys, height, err := c.DelegatorCandidates(delegator)
+	if err != nil {
+		return nil, height, err
+	}
+	at := c.WithHeight(height)
+	for _, pk := range pubKeys {
+		bond, _, err := at.Bond(delegator, pk)
+		if err != nil {
+			return nil, height, err
+		}
+		bonds = append(bonds, *bond)
+	}
+	return bonds, height, nil
+}
+
+// WithdrawAddress - the account the unbonded coins of a delegator are
+// returned to, the delegator itself unless it set a withdraw address
+func (c Client) WithdrawAddress(delegator sdk.Actor) (address sdk.Actor, height int64, err error) {
+	height, err = c.get(stake.GetWithdrawAddressKey(delegator), &address)
+	if client.IsNoDataErr(err) {
+		return delegator, height, nil
+	}
+	return
+}
+
+// UpgradePlan - the scheduled software upgrade
+func (c Client) UpgradePlan() (plan stake.UpgradePlan, height int64, err error) {
+	height, err = c.get(stake.UpgradePlanKey, &plan)
+	return
+}
+
+// UpgradeSignal - the name of the software upgrade signalled by a candidate
+func (c Client) UpgradeSignal(pubKey crypto.PubKey) (name string, height int64, err error) {
+	height, err = c.get(stake.GetUpgradeSignalKey(pubKey), &name)
+	return
+}
+
+// NextSequence - the nonce of the next tx signed by a signer, 1 for its
+// first tx
+func (c Client) NextSequence(signer sdk.Actor) (seq uint32, height int64, err error) {
+	key := nonce.GetSeqKey([]sdk.Actor{coin.ChainAddr(signer)})
+	height, err = c.read(stack.PrefixedKey(nonce.NameNonce, key), &seq)
+	if client.IsNoDataErr(err) {
+		return 1, height, nil
+	}
+	return seq + 1, height, err
+}
+
+// Broadcast - send a signed tx to the node and wait for its commit
+func (c Client) Broadcast(tx sdk.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	return c.node.BroadcastTxCommit(wire.BinaryBytes(tx))
+}
+
+// query - read a custom query path of the stake module into data. The
+// results are computed by the node and cannot be proven.
+func (c Client) query(path string, data interface{}) (height int64, err error) {
+	resp, err := c.node.ABCIQueryWithOptions(path, nil,
+		rpcclient.ABCIQueryOptions{Trusted: true, Height: c.height})
+	if err != nil {
+		return 0, err
+	}
+	if resp.Response.IsErr() {
+		return 0, errors.Errorf("Query error %d: %s", resp.Response.Code, resp.Response.Log)
+	}
+	return resp.Response.Height, wire.ReadBinaryBytes(resp.Response.Value, data)
+}
+
+// Validators - the validators ranked by voting power, computed by the node
+func (c Client) Validators() (validators []stake.RankedValidator, height int64, err error) {
+	height, err = c.query(stake.QueryValidators, &validators)
+	return
+}
+
+// DelegationValues - the bonds of a delegator with their candidates and
+// values, computed by the node
+func (c Client) DelegationValues(delegator sdk.Actor) (delegations []stake.Delegation, height int64, err error) {
+	path := fmt.Sprintf("%s%s:%s:%X", stake.QueryDelegations,
+		delegator.ChainID, delegator.App, []byte(delegator.Address))
+	height, err = c.query(path, &delegations)
+	return
+}
+
+// RankedCandidates - the candidates ranked by voting power, computed by
+// the node
+func (c Client) RankedCandidates() (ranked []stake.RankedCandidate, height int64, err error) {
+	height, err = c.query(stake.QueryCandidates, &ranked)
+	for _, r := range ranked {
+		if len(r.Candidate.OwnerChanges) == 0 {
+			r.Candidate.OwnerChanges = nil // decoded as an empty slice
+		}
+	}
+	return
+}
+
+// Pool - the tokens bonded on the chain, computed by the node
+func (c Client) Pool() (pool stake.Pool, height int64, err error) {
+	height, err = c.query(stake.QueryPool, &pool)
+	return
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"fmt"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/auth"
+	"github.com/cosmos/cosmos-sdk/modules/base"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/modules/fee"
+	"github.com/cosmos/cosmos-sdk/modules/nonce"
+	"github.com/cosmos/cosmos-sdk/modules/roles"
+
+	"github.com/cosmos/gaia/modules/stake"
+)
+
+// TxOptions - the envelope of a stake tx: the optional fees and role, the
+// signer and its nonce. A tx which assumes a role is sent by the role, and
+// it must be signed by enough of its signers, collected with Multi.
+type TxOptions struct {
+	Fees     *coin.Coin
+	From     sdk.Actor
+	Sequence uint32
+	Role     []byte
+	Multi    bool
+}
+
+// Validate - the problems of the envelope of a tx to the candidate of a
+// pubkey, named after the fields of the REST inputs
+func (o TxOptions) Validate(pubKey crypto.PubKey) (problems []string) {
+	if o.From.Empty() {
+		problems = append(problems, `"from" cannot be nil`)
+	}
+	if o.Sequence <= 0 {
+		problems = append(problems, `"sequence" must be > 0`)
+	}
+	if pubKey.Empty() {
+		problems = append(problems, `"pubkey" cannot be empty`)
+	}
+	return
+}
+
+// DeclareProblems - the problems of the description of a new candidate,
+// named after the fields of the REST inputs
+func DeclareProblems(description stake.Description) (problems []string) {
+	if description.Moniker == "" {
+		problems = append(problems, fmt.Sprintf("%q cannot be empty", "name"))
+	}
+	return
+}
+
+// EditProblems - the problems of the description of an edit, named after
+// the fields of the REST inputs
+func EditProblems(description stake.Description) (problems []string) {
+	if description == (stake.Description{}) {
+		problems = append(problems, fmt.Sprintf("one of %q, %q, %q or %q must be set",
+			"name", "keybase", "website", "details"))
+	}
+	return
+}
+
+// BuildTx - wrap a stake tx in its envelope, ready to be signed
+func (c Client) BuildTx(tx sdk.Tx, opts TxOptions) sdk.Tx {
+
+	// fees are optional
+	if opts.Fees != nil && !opts.Fees.IsZero() {
+		tx = fee.NewFee(tx, *opts.Fees, opts.From)
+	}
+	if len(opts.Role) > 0 {
+		tx = roles.NewAssumeRoleTx(opts.Role, tx)
+	}
+	// only add the actual signer to the nonce
+	signers := []sdk.Actor{opts.From}
+	tx = nonce.NewTx(opts.Sequence, signers, tx)
+	tx = base.NewChainTx(c.chainID, 0, tx)
+
+	if opts.Multi {
+		return auth.NewMulti(tx).Wrap()
+	}
+	return auth.NewSig(tx).Wrap()
+}
+
+// DeclareCandidacy - build a tx declaring a candidate with a self-bond
+func (c Client) DeclareCandidacy(bond coin.Coin, pubKey crypto.PubKey,
+	description stake.Description, opts TxOptions) sdk.Tx {
+	return c.BuildTx(stake.NewTxDeclareCandidacy(bond, pubKey, description), opts)
+}
+
+// EditCandidacy - build a tx editing the description of a candidate
+func (c Client) EditCandidacy(pubKey crypto.PubKey, description stake.Description,
+	opts TxOptions) sdk.Tx {
+	return c.BuildTx(stake.NewTxEditCandidacy(pubKey, description), opts)
+}
+
+// Delegate - build a tx delegating coins to a candidate
+func (c Client) Delegate(bond coin.Coin, pubKey crypto.PubKey, opts TxOptions) sdk.Tx {
+	return c.BuildTx(stake.NewTxDelegate(bond, pubKey), opts)
+}
+
+// Unbond - build a tx unbonding shares from a candidate
+func (c Client) Unbond(shares uint64, pubKey crypto.PubKey, opts TxOptions) sdk.Tx {
+	return c.BuildTx(stake.NewTxUnbond(shares, pubKey), opts)
+}
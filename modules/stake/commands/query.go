@@ -5,13 +5,14 @@ import (
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
-	crypto "github.com/tendermint/go-crypto"
+	"github.com/tendermint/tendermint/lite"
 
+	sdk "github.com/cosmos/cosmos-sdk"
 	"github.com/cosmos/cosmos-sdk/client/commands"
 	"github.com/cosmos/cosmos-sdk/client/commands/query"
 	"github.com/cosmos/cosmos-sdk/modules/coin"
-	"github.com/cosmos/cosmos-sdk/stack"
-	"github.com/cosmos/gaia/modules/stake"
+
+	stakeclient "github.com/cosmos/gaia/modules/stake/client"
 )
 
 //nolint
@@ -40,6 +41,30 @@ var (
 		Short: "Query all delegators candidates' pubkeys based on address",
 	}
 
+	CmdQueryUpgradePlan = &cobra.Command{
+		Use:   "upgrade-plan",
+		Short: "Query the scheduled software upgrade and its halt height",
+		RunE:  cmdQueryUpgradePlan,
+	}
+
+	CmdQueryUpgradeSignal = &cobra.Command{
+		Use:   "upgrade-signal",
+		Short: "Query the software upgrade signalled by a validator-candidate",
+		RunE:  cmdQueryUpgradeSignal,
+	}
+
+	CmdQueryEpoch = &cobra.Command{
+		Use:   "epoch",
+		Short: "Query the current epoch and the height the next one starts at",
+		RunE:  cmdQueryEpoch,
+	}
+
+	CmdQueryWithdrawAddress = &cobra.Command{
+		Use:   "withdraw-address",
+		Short: "Query the account the unbonded coins of a delegator are returned to",
+		RunE:  cmdQueryWithdrawAddress,
+	}
+
 	FlagDelegatorAddress = "delegator-address"
 )
 
@@ -54,83 +79,165 @@ func init() {
 	CmdQueryDelegatorBond.Flags().AddFlagSet(fsPk)
 	CmdQueryDelegatorBond.Flags().AddFlagSet(fsAddr)
 	CmdQueryDelegatorCandidates.Flags().AddFlagSet(fsAddr)
+	CmdQueryWithdrawAddress.Flags().AddFlagSet(fsAddr)
+	CmdQueryUpgradeSignal.Flags().AddFlagSet(fsPk)
 }
 
-func cmdQueryCandidates(cmd *cobra.Command, args []string) error {
+// GetClient - the client of the stake module for the node, chain and height
+// of the flags, proving the state unless the node is trusted
+func GetClient() (stakeclient.Client, error) {
+	var cert lite.Certifier
+	if !viper.GetBool(commands.FlagTrustNode) {
+		inquiring, err := commands.GetCertifier()
+		if err != nil {
+			return stakeclient.Client{}, err
+		}
+		cert = inquiring
+	}
+	c := stakeclient.New(commands.GetChainID(), commands.GetNode(), cert)
+	return c.WithHeight(query.GetHeight()), nil
+}
 
-	var pks []crypto.PubKey
+// GetTxClient - the client building the txs of the chain of the flags, it
+// does not query the node
+func GetTxClient() stakeclient.Client {
+	return stakeclient.New(commands.GetChainID(), nil, nil)
+}
+
+// GetDelegator - parse the address of a delegator
+func GetDelegator(address string) (sdk.Actor, error) {
+	delegator, err := commands.ParseActor(address)
+	if err != nil {
+		return delegator, err
+	}
+	return coin.ChainAddr(delegator), nil
+}
 
-	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.CandidatesPubKeysKey)
-	height, err := query.GetParsed(key, &pks, query.GetHeight(), prove)
+func cmdQueryCandidates(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
 	if err != nil {
 		return err
 	}
 
+	pks, height, err := c.CandidatesPubKeys()
+	if err != nil {
+		return err
+	}
 	return query.OutputProof(pks, height)
 }
 
 func cmdQueryCandidate(cmd *cobra.Command, args []string) error {
-
-	var candidate stake.Candidate
-
 	pk, err := GetPubKey(viper.GetString(FlagPubKey))
 	if err != nil {
 		return err
 	}
-
-	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.GetCandidateKey(pk))
-	height, err := query.GetParsed(key, &candidate, query.GetHeight(), prove)
+	c, err := GetClient()
 	if err != nil {
 		return err
 	}
 
+	candidate, height, err := c.Candidate(pk)
+	if err != nil {
+		return err
+	}
 	return query.OutputProof(candidate, height)
 }
 
 func cmdQueryDelegatorBond(cmd *cobra.Command, args []string) error {
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+	delegator, err := GetDelegator(viper.GetString(FlagDelegatorAddress))
+	if err != nil {
+		return err
+	}
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
+
+	bond, height, err := c.Bond(delegator, pk)
+	if err != nil {
+		return err
+	}
+	return query.OutputProof(bond, height)
+}
+
+func cmdQueryDelegatorCandidates(cmd *cobra.Command, args []string) error {
+	delegator, err := GetDelegator(viper.GetString(FlagDelegatorAddress))
+	if err != nil {
+		return err
+	}
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
 
-	var bond stake.DelegatorBond
+	candidates, height, err := c.DelegatorCandidates(delegator)
+	if err != nil {
+		return err
+	}
+	return query.OutputProof(candidates, height)
+}
 
-	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+func cmdQueryUpgradePlan(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
 	if err != nil {
 		return err
 	}
 
-	delegatorAddr := viper.GetString(FlagDelegatorAddress)
-	delegator, err := commands.ParseActor(delegatorAddr)
+	plan, height, err := c.UpgradePlan()
 	if err != nil {
 		return err
 	}
-	delegator = coin.ChainAddr(delegator)
+	return query.OutputProof(plan, height)
+}
 
-	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondKey(delegator, pk))
-	height, err := query.GetParsed(key, &bond, query.GetHeight(), prove)
+func cmdQueryEpoch(cmd *cobra.Command, args []string) error {
+	c, err := GetClient()
 	if err != nil {
 		return err
 	}
 
-	return query.OutputProof(bond, height)
+	epoch, height, err := c.Epoch()
+	if err != nil {
+		return err
+	}
+	return query.OutputProof(epoch, height)
 }
 
-func cmdQueryDelegatorCandidates(cmd *cobra.Command, args []string) error {
+func cmdQueryUpgradeSignal(cmd *cobra.Command, args []string) error {
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+	c, err := GetClient()
+	if err != nil {
+		return err
+	}
 
-	delegatorAddr := viper.GetString(FlagDelegatorAddress)
-	delegator, err := commands.ParseActor(delegatorAddr)
+	name, height, err := c.UpgradeSignal(pk)
 	if err != nil {
 		return err
 	}
-	delegator = coin.ChainAddr(delegator)
+	return query.OutputProof(name, height)
+}
 
-	prove := !viper.GetBool(commands.FlagTrustNode)
-	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondsKey(delegator))
-	var candidates []crypto.PubKey
-	height, err := query.GetParsed(key, &candidates, query.GetHeight(), prove)
+func cmdQueryWithdrawAddress(cmd *cobra.Command, args []string) error {
+	delegator, err := GetDelegator(viper.GetString(FlagDelegatorAddress))
+	if err != nil {
+		return err
+	}
+	c, err := GetClient()
 	if err != nil {
 		return err
 	}
 
-	return query.OutputProof(candidates, height)
+	// without a withdraw address the coins return to the delegator
+	address, height, err := c.WithdrawAddress(delegator)
+	if err != nil {
+		return err
+	}
+	return query.OutputProof(address, height)
 }
@@ -10,6 +10,8 @@ import (
 
 	crypto "github.com/tendermint/go-crypto"
 
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client/commands"
 	txcmd "github.com/cosmos/cosmos-sdk/client/commands/txs"
 	"github.com/cosmos/cosmos-sdk/modules/coin"
 
@@ -26,6 +28,13 @@ const (
 	FlagIdentity = "keybase-sig"
 	FlagWebsite  = "website"
 	FlagDetails  = "details"
+
+	FlagUpgradeName = "upgrade-name"
+	FlagNewPubKey   = "new-pubkey"
+	FlagNewOwner    = "new-owner"
+	FlagOperator    = "operator"
+	FlagAddress     = "address"
+	FlagMaxShares   = "max-shares"
 )
 
 // nolint
@@ -45,11 +54,61 @@ var (
 		Short: "delegate coins to an existing validator/candidate",
 		RunE:  cmdDelegate,
 	}
+	CmdSignalUpgrade = &cobra.Command{
+		Use:   "signal-upgrade",
+		Short: "signal a validator-candidate is ready for a named software upgrade",
+		RunE:  cmdSignalUpgrade,
+	}
 	CmdUnbond = &cobra.Command{
 		Use:   "unbond",
 		Short: "unbond coins from a validator/candidate",
 		RunE:  cmdUnbond,
 	}
+	CmdRotateConsensusKey = &cobra.Command{
+		Use:   "rotate-consensus-key",
+		Short: "replace the consensus pubkey of a validator-candidate, keeping its bonds",
+		RunE:  cmdRotateConsensusKey,
+	}
+	CmdProposeOwner = &cobra.Command{
+		Use:   "propose-owner",
+		Short: "propose a new owner for a validator-candidate, who must accept the ownership",
+		RunE:  cmdProposeOwner,
+	}
+	CmdAcceptOwner = &cobra.Command{
+		Use:   "accept-owner",
+		Short: "accept the ownership of a validator-candidate proposed by its owner",
+		RunE:  cmdAcceptOwner,
+	}
+	CmdSetOperator = &cobra.Command{
+		Use:   "set-operator",
+		Short: "set the operator which can edit a validator-candidate without access to its funds",
+		RunE:  cmdSetOperator,
+	}
+	CmdSetWithdrawAddress = &cobra.Command{
+		Use:   "set-withdraw-address",
+		Short: "set the account the unbonded coins of the sender are returned to",
+		RunE:  cmdSetWithdrawAddress,
+	}
+	CmdRevokeCandidacy = &cobra.Command{
+		Use:   "revoke-candidacy",
+		Short: "remove a validator-candidate and return all its bonds to their delegators",
+		RunE:  cmdRevokeCandidacy,
+	}
+	CmdPauseCandidacy = &cobra.Command{
+		Use:   "pause-candidacy",
+		Short: "leave the validator set for maintenance, keeping the bonds of the validator-candidate",
+		RunE:  cmdPauseCandidacy,
+	}
+	CmdResumeCandidacy = &cobra.Command{
+		Use:   "resume-candidacy",
+		Short: "rejoin the validator election after a maintenance",
+		RunE:  cmdResumeCandidacy,
+	}
+	CmdSetDelegationCap = &cobra.Command{
+		Use:   "set-delegation-cap",
+		Short: "set the maximum shares a validator-candidate accepts from delegations",
+		RunE:  cmdSetDelegationCap,
+	}
 )
 
 func init() {
@@ -70,6 +129,9 @@ func init() {
 	fsCandidate.String(FlagWebsite, "", "optional website")
 	fsCandidate.String(FlagDetails, "", "optional detailed description space")
 
+	fsUpgrade := flag.NewFlagSet("", flag.ContinueOnError)
+	fsUpgrade.String(FlagUpgradeName, "", "name of the software upgrade")
+
 	// add the flags
 	CmdDelegate.Flags().AddFlagSet(fsPk)
 	CmdDelegate.Flags().AddFlagSet(fsAmount)
@@ -83,6 +145,29 @@ func init() {
 
 	CmdEditCandidacy.Flags().AddFlagSet(fsPk)
 	CmdEditCandidacy.Flags().AddFlagSet(fsCandidate)
+
+	CmdSignalUpgrade.Flags().AddFlagSet(fsPk)
+	CmdSignalUpgrade.Flags().AddFlagSet(fsUpgrade)
+
+	CmdRotateConsensusKey.Flags().AddFlagSet(fsPk)
+	CmdRotateConsensusKey.Flags().String(FlagNewPubKey, "", "new consensus PubKey of the validator-candidate")
+
+	CmdProposeOwner.Flags().AddFlagSet(fsPk)
+	CmdProposeOwner.Flags().String(FlagNewOwner, "", "address of the proposed owner")
+
+	CmdAcceptOwner.Flags().AddFlagSet(fsPk)
+
+	CmdSetOperator.Flags().AddFlagSet(fsPk)
+	CmdSetOperator.Flags().String(FlagOperator, "", "address of the operator, leave empty to remove the operator")
+
+	CmdRevokeCandidacy.Flags().AddFlagSet(fsPk)
+	CmdPauseCandidacy.Flags().AddFlagSet(fsPk)
+	CmdResumeCandidacy.Flags().AddFlagSet(fsPk)
+
+	CmdSetDelegationCap.Flags().AddFlagSet(fsPk)
+	CmdSetDelegationCap.Flags().Int64(FlagMaxShares, 0, "maximum shares of the validator-candidate, 0 to remove the cap")
+
+	CmdSetWithdrawAddress.Flags().String(FlagAddress, "", "withdraw address, leave empty to withdraw to the sender")
 }
 
 func cmdDeclareCandidacy(cmd *cobra.Command, args []string) error {
@@ -161,6 +246,138 @@ func cmdUnbond(cmd *cobra.Command, args []string) error {
 	return txcmd.DoTx(tx)
 }
 
+func cmdSignalUpgrade(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	name := viper.GetString(FlagUpgradeName)
+	if name == "" {
+		return fmt.Errorf("please enter the name of the upgrade using --%s", FlagUpgradeName)
+	}
+
+	tx := stake.NewTxSignalUpgrade(pk, name)
+	return txcmd.DoTx(tx)
+}
+
+func cmdRotateConsensusKey(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	if viper.GetString(FlagNewPubKey) == "" {
+		return fmt.Errorf("please enter the new pubkey using --%s", FlagNewPubKey)
+	}
+	newPk, err := GetPubKey(viper.GetString(FlagNewPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxRotateConsensusKey(pk, newPk)
+	return txcmd.DoTx(tx)
+}
+
+func cmdProposeOwner(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	if viper.GetString(FlagNewOwner) == "" {
+		return fmt.Errorf("please enter the address of the new owner using --%s", FlagNewOwner)
+	}
+	newOwner, err := commands.ParseActor(viper.GetString(FlagNewOwner))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxProposeOwner(pk, newOwner)
+	return txcmd.DoTx(tx)
+}
+
+func cmdAcceptOwner(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxAcceptOwner(pk)
+	return txcmd.DoTx(tx)
+}
+
+func cmdSetOperator(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	var operator sdk.Actor
+	if viper.GetString(FlagOperator) != "" {
+		operator, err = commands.ParseActor(viper.GetString(FlagOperator))
+		if err != nil {
+			return err
+		}
+	}
+
+	tx := stake.NewTxSetOperator(pk, operator)
+	return txcmd.DoTx(tx)
+}
+
+func cmdSetWithdrawAddress(cmd *cobra.Command, args []string) error {
+
+	var address sdk.Actor
+	if viper.GetString(FlagAddress) != "" {
+		var err error
+		address, err = commands.ParseActor(viper.GetString(FlagAddress))
+		if err != nil {
+			return err
+		}
+	}
+
+	tx := stake.NewTxSetWithdrawAddress(address)
+	return txcmd.DoTx(tx)
+}
+
+func cmdRevokeCandidacy(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxRevokeCandidacy(pk)
+	return txcmd.DoTx(tx)
+}
+
+func cmdPauseCandidacy(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxPauseCandidacy(pk)
+	return txcmd.DoTx(tx)
+}
+
+func cmdResumeCandidacy(cmd *cobra.Command, args []string) error {
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxResumeCandidacy(pk)
+	return txcmd.DoTx(tx)
+}
+
 // GetPubKey - create the pubkey from a pubkey string
 func GetPubKey(pubKeyStr string) (pk crypto.PubKey, err error) {
 
@@ -182,3 +399,19 @@ func GetPubKey(pubKeyStr string) (pk crypto.PubKey, err error) {
 	pk = pkEd.Wrap()
 	return
 }
+
+func cmdSetDelegationCap(cmd *cobra.Command, args []string) error {
+
+	maxShares := viper.GetInt64(FlagMaxShares)
+	if maxShares < 0 {
+		return fmt.Errorf("max shares must be a non-negative integer")
+	}
+
+	pk, err := GetPubKey(viper.GetString(FlagPubKey))
+	if err != nil {
+		return err
+	}
+
+	tx := stake.NewTxSetDelegationCap(pk, uint64(maxShares))
+	return txcmd.DoTx(tx)
+}
@@ -0,0 +1,168 @@
+package stake
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// nolint - names of the election policies, selected by the election_policy
+// param
+const (
+	ElectionTopN      = "top_n"
+	ElectionRandom    = "random"
+	ElectionCapped    = "capped"
+	ElectionQuadratic = "quadratic"
+)
+
+// ElectionPolicy - the rule electing the validators among the candidates.
+// Elect is passed the candidates sorted by their power if elected, held in
+// VotingPower and zero for the candidates which cannot be elected, and sets
+// the VotingPower each candidate moves to, zero if it is not elected. The
+// seed is the hash of the block.
+type ElectionPolicy interface {
+	Elect(candidates Candidates, params Params, seed []byte)
+}
+
+// SeededElectionPolicy - an election policy drawing the validators with the
+// seed, the hash of the block is only recorded for the seeded policies
+type SeededElectionPolicy interface {
+	ElectionPolicy
+	Seeded()
+}
+
+var electionPolicies = map[string]ElectionPolicy{
+	ElectionTopN:      topNElection{},
+	ElectionRandom:    randomElection{},
+	ElectionCapped:    cappedElection{},
+	ElectionQuadratic: quadraticElection{},
+}
+
+// RegisterElectionPolicy - make an election policy selectable by its name
+// with the election_policy param
+func RegisterElectionPolicy(name string, policy ElectionPolicy) {
+	if _, ok := electionPolicies[name]; ok {
+		panic(fmt.Sprintf("election policy %q already registered", name))
+	}
+	electionPolicies[name] = policy
+}
+
+// the policy selected by the params. The params only select registered
+// policies, a policy missing from this binary stops the node rather than
+// elect another validator set than the binaries which have it.
+func electionPolicy(params Params) ElectionPolicy {
+	policy, ok := electionPolicies[params.ElectionPolicy]
+	if !ok {
+		panic(ErrUnknownElection(params.ElectionPolicy))
+	}
+	return policy
+}
+
+// topNElection - the MaxVals candidates with the most shares are elected
+type topNElection struct{}
+
+func (topNElection) Elect(candidates Candidates, params Params, seed []byte) {
+	for i, c := range candidates {
+		if i >= int(params.MaxVals) {
+			c.VotingPower = 0
+		}
+	}
+}
+
+// randomElection - MaxVals candidates are drawn with a probability
+// proportional to their shares, the draws are seeded by the block hash
+type randomElection struct{}
+
+var _ SeededElectionPolicy = randomElection{} // enforce interface at compile time
+
+// Seeded - the draws use the seed
+func (randomElection) Seeded() {}
+
+func (randomElection) Elect(candidates Candidates, params Params, seed []byte) {
+	var total uint64
+	remaining := make(Candidates, 0, len(candidates))
+	for _, c := range candidates {
+		if c.VotingPower > 0 {
+			total += c.VotingPower
+			remaining = append(remaining, c)
+		}
+	}
+
+	elected := make(map[*Candidate]bool)
+	for draw := 0; draw < int(params.MaxVals) && len(remaining) > 0; draw++ {
+		// the draw is a point in the power of the remaining candidates
+		b := make([]byte, len(seed)+8)
+		copy(b, seed)
+		binary.BigEndian.PutUint64(b[len(seed):], uint64(draw))
+		hash := sha256.Sum256(b)
+		point := binary.BigEndian.Uint64(hash[:8]) % total
+
+		for i, c := range remaining {
+			if point >= c.VotingPower {
+				point -= c.VotingPower
+				continue
+			}
+			elected[c] = true
+			total -= c.VotingPower
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			break
+		}
+	}
+
+	for _, c := range candidates {
+		if !elected[c] {
+			c.VotingPower = 0
+		}
+	}
+}
+
+// cappedElection - the top candidates are elected, the power of each
+// validator is capped to the ElectionPowerCap percent of the power of
+// all the validators
+type cappedElection struct{}
+
+func (cappedElection) Elect(candidates Candidates, params Params, seed []byte) {
+	topNElection{}.Elect(candidates, params, seed)
+	if params.ElectionPowerCap == 0 {
+		return
+	}
+
+	var total uint64
+	for _, c := range candidates {
+		total += c.VotingPower
+	}
+	max := total * uint64(params.ElectionPowerCap) / 100
+	if max == 0 {
+		max = 1 // a validator keeps some power
+	}
+	for _, c := range candidates {
+		if c.VotingPower > max {
+			c.VotingPower = max
+		}
+	}
+}
+
+// quadraticElection - the top candidates are elected, with the square root
+// of their shares as power
+type quadraticElection struct{}
+
+func (quadraticElection) Elect(candidates Candidates, params Params, seed []byte) {
+	topNElection{}.Elect(candidates, params, seed)
+	for _, c := range candidates {
+		c.VotingPower = sqrt(c.VotingPower)
+	}
+}
+
+// sqrt - the integer square root, rounded down
+func sqrt(n uint64) uint64 {
+	if n < 2 {
+		return n
+	}
+	x := n
+	y := x/2 + x%2
+	for y < x {
+		x = y
+		y = (x + n/x) / 2
+	}
+	return x
+}
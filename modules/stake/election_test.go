@@ -0,0 +1,107 @@
+package stake
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+func TestElectionPolicies(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	actors := newActors(5)
+	elected := func(cs Candidates) (n int) {
+		for _, c := range cs {
+			if c.TargetPower > 0 {
+				n++
+			}
+		}
+		return
+	}
+
+	params := loadParams(store)
+	params.MaxVals = 3
+	saveParams(store, params)
+	require.Equal(ElectionTopN, params.ElectionPolicy)
+
+	// only the seeded policies record the hash of the block
+	SaveElectionSeed(store, []byte("block hash"))
+	assert.False(store.Has(ElectionSeedKey))
+
+	// the top candidates by default
+	candidates := candidatesFromActors(actors, []int{400, 300, 200, 100, 50})
+	candidates.updateVotingPower(store)
+	assert.Equal(3, elected(candidates))
+	assert.Equal(uint64(0), candidates[3].TargetPower)
+
+	// the square root of the shares
+	require.NoError(SetParam(store, "election_policy", ElectionQuadratic))
+	candidates = candidatesFromActors(actors, []int{400, 100, 9, 4, 1})
+	candidates.updateVotingPower(store)
+	assert.Equal([]uint64{20, 10, 3, 0, 0}, []uint64{candidates[0].VotingPower,
+		candidates[1].VotingPower, candidates[2].VotingPower,
+		candidates[3].VotingPower, candidates[4].VotingPower})
+	assert.Equal(uint64(3), sqrt(15))
+	assert.Equal(uint64(4294967295), sqrt(1<<64-1))
+
+	// the power capped to a fraction of the power of the validators
+	require.NoError(SetParam(store, "election_policy", ElectionCapped))
+	require.NoError(SetParam(store, "election_power_cap", "40"))
+	candidates = candidatesFromActors(actors, []int{700, 200, 100, 50, 10})
+	candidates.updateVotingPower(store)
+	assert.Equal(uint64(400), candidates[0].VotingPower)
+	assert.Equal(uint64(200), candidates[1].VotingPower)
+	assert.Zero(candidates[3].VotingPower)
+
+	// the random draws depend on the seed only
+	require.NoError(SetParam(store, "election_policy", ElectionRandom))
+	SaveElectionSeed(store, []byte("block hash"))
+	draw := func() (powers []uint64) {
+		candidates = candidatesFromActors(actors, []int{400, 300, 200, 100, 50})
+		candidates.updateVotingPower(store)
+		assert.Equal(3, elected(candidates))
+		for _, pk := range pks[:5] {
+			powers = append(powers, loadCandidate(store, pk).TargetPower)
+		}
+		return
+	}
+	first := draw()
+	assert.Equal(first, draw())
+	differs := false
+	for i := 0; i < 10 && !differs; i++ {
+		SaveElectionSeed(store, []byte{byte(i)})
+		differs = !reflect.DeepEqual(first, draw())
+	}
+	assert.True(differs)
+
+	// all the eligible candidates are elected when they fit
+	params = loadParams(store)
+	params.MaxVals = 10
+	saveParams(store, params)
+	candidates = candidatesFromActors(actors, []int{400, 300, 200, 100, 50})
+	candidates.updateVotingPower(store)
+	assert.Equal(5, elected(candidates))
+}
+
+func TestUnknownElectionPolicy(t *testing.T) {
+	assert := assert.New(t)
+	store := state.NewMemKVStore()
+
+	// the params and the genesis only select registered policies
+	err := SetParam(store, "election_policy", "lottery")
+	assert.True(IsUnknownElectionErr(err), "%v", err)
+	params := defaultParams()
+	params.ElectionPolicy = "lottery"
+	err = NewHandler(WithParams(params)).initState(stakingModuleName, "max_vals", "5", store)
+	assert.True(IsUnknownElectionErr(err), "%v", err)
+	assert.False(store.Has(ParamKey))
+
+	// a policy missing from the binary stops the election
+	saveParams(store, params)
+	candidates := candidatesFromActors(newActors(2), []int{20, 10})
+	assert.Panics(func() { candidates.updateVotingPower(store) })
+}
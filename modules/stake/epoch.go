@@ -0,0 +1,240 @@
+package stake
+
+import (
+	abci "github.com/tendermint/abci/types"
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// Epoch - the blocks between two updates of the validator set, the epoch
+// length param applies from the next epoch
+type Epoch struct {
+	Number      int64 `json:"number"`
+	StartHeight int64 `json:"start_height"`
+	NextHeight  int64 `json:"next_height"` // height the next epoch starts at
+}
+
+// QueuedBond - a delegation or an unbond made during an epoch, applied to
+// the bonds at the start of the next epoch. The coins of a delegation are
+// already held by the hold account.
+type QueuedBond struct {
+	Delegator sdk.Actor     `json:"delegator"`
+	PubKey    crypto.PubKey `json:"pub_key"`
+	Shares    uint64        `json:"shares"`
+	Unbond    bool          `json:"unbond"`
+}
+
+// QueuedBonds - the delegations and unbonds of the current epoch, in the
+// order they are applied
+type QueuedBonds []QueuedBond
+
+// shares - the shares queued to be delegated to and unbonded from a
+// candidate by a delegator, an empty pubKey or delegator matches them all
+func (qs QueuedBonds) shares(pubKey crypto.PubKey, delegator sdk.Actor) (delegated, unbonded uint64) {
+	for _, q := range qs {
+		if !pubKey.Empty() && !q.PubKey.Equals(pubKey) {
+			continue
+		}
+		if !delegator.Empty() && !q.Delegator.Equals(delegator) {
+			continue
+		}
+		if q.Unbond {
+			unbonded += q.Shares
+		} else {
+			delegated += q.Shares
+		}
+	}
+	return
+}
+
+// sharesAfterEpoch - the shares once the queued delegations and unbonds are
+// applied, the unbonds of a bond revoked since are not applied
+func sharesAfterEpoch(shares, delegated, unbonded uint64) uint64 {
+	if shares+delegated < unbonded {
+		return delegated
+	}
+	return shares + delegated - unbonded
+}
+
+// delegates - whether a delegator has a delegation to a candidate queued
+func (qs QueuedBonds) delegates(pubKey crypto.PubKey, delegator sdk.Actor) bool {
+	delegated, _ := qs.shares(pubKey, delegator)
+	return delegated > 0
+}
+
+// newDelegators - the number of delegators without a bond which have a
+// delegation to a candidate queued
+func (qs QueuedBonds) newDelegators(store state.SimpleDB, pubKey crypto.PubKey) (count int64) {
+	counted := make(map[string]bool)
+	for _, q := range qs {
+		if q.Unbond || !q.PubKey.Equals(pubKey) || counted[q.Delegator.String()] {
+			continue
+		}
+		counted[q.Delegator.String()] = true
+		if loadDelegatorBond(store, q.Delegator, pubKey) == nil {
+			count++
+		}
+	}
+	return
+}
+
+// ProcessEpoch - start a new epoch at the end of the current one: the
+// delegations and unbonds queued during the epoch are applied to the bonds,
+// then the validator set is updated. Between two epochs the validator set
+// does not change. It needs the global store, and dispatch to route the
+// returned coins to the coin module, e.g. stack.NewDispatcher(coin.NewHandler()).
+func ProcessEpoch(ctx sdk.Context, store state.SimpleDB, dispatch sdk.Deliver) (change []*abci.Validator, err error) {
+	return NewHandler().ProcessEpoch(ctx, store, dispatch)
+}
+
+// ProcessEpoch - ProcessEpoch calling the hooks of the handler on the
+// events of the txs of the block, on the queued bonds and on the validator
+// set update, the coins are returned with the coin backend of the handler.
+// It must be called at the end of each block.
+func (h Handler) ProcessEpoch(ctx sdk.Context, store state.SimpleDB, dispatch sdk.Deliver) (change []*abci.Validator, err error) {
+	stakeStore := stack.PrefixedStore(stakingModuleName, store)
+	height := ctx.BlockHeight()
+	h.hooks.processEvents(ctx, store)
+
+	epoch := loadEpoch(stakeStore)
+	if height < epoch.NextHeight {
+		return nil, nil
+	}
+
+	params := loadParams(stakeStore)
+	err = h.applyQueuedBonds(ctx, store, dispatch, params)
+	if err != nil {
+		return nil, err
+	}
+	saveEpoch(stakeStore, Epoch{
+		Number:      epoch.Number + 1,
+		StartHeight: height,
+		NextHeight:  height + params.EpochLength,
+	})
+	h.hooks.processEvents(ctx, store)
+	return h.UpdateValidatorSet(ctx, store)
+}
+
+// applyQueuedBonds - apply the delegations and unbonds of the epoch, then
+// clear the queue. Each bond is applied on a checkpoint of the global store:
+// a bond which fails is dropped with its changes, the coins of a delegation
+// dropped are returned and the bonded shares of an unbond dropped stay
+// bonded.
+func (h Handler) applyQueuedBonds(ctx sdk.Context, store state.SimpleDB,
+	dispatch sdk.Deliver, params Params) error {
+	stakeStore := stack.PrefixedStore(stakingModuleName, store)
+	queue := loadQueuedBonds(stakeStore)
+	if len(queue) == 0 {
+		return nil
+	}
+
+	// the context of the returns has the permission of the hold account
+	ctx2 := ctx.WithPermissions(params.HoldAccount)
+	for _, q := range queue {
+		cp := store.Checkpoint()
+		transfer := h.newCoinSend(ctx2, cp, dispatch).TransferFn
+		err := applyQueuedBond(ctx, stack.PrefixedStore(stakingModuleName, cp),
+			transfer, params, h.hooks, q)
+		if err != nil {
+			ctx.Error("Queued bond dropped", "pubkey", q.PubKey,
+				"delegator", q.Delegator, "shares", q.Shares, "unbond", q.Unbond,
+				"err", err.Error())
+			if !q.Unbond {
+				h.returnDroppedDelegation(ctx2, store, dispatch, params, q)
+			}
+			continue
+		}
+		err = store.Commit(cp)
+		if err != nil {
+			return err
+		}
+	}
+	removeQueuedBonds(stakeStore)
+	return nil
+}
+
+// returnDroppedDelegation - return the coins of a dropped delegation from
+// the hold account, on a checkpoint of the global store. Coins which cannot
+// be returned are logged and stay in the hold account.
+func (h Handler) returnDroppedDelegation(ctx sdk.Context, store state.SimpleDB,
+	dispatch sdk.Deliver, params Params, q QueuedBond) {
+	cp := store.Checkpoint()
+	transfer := h.newCoinSend(ctx, cp, dispatch).TransferFn
+	err := returnQueuedDelegation(stack.PrefixedStore(stakingModuleName, cp),
+		transfer, params, q)
+	if err == nil {
+		err = store.Commit(cp)
+	}
+	if err != nil {
+		ctx.Error("Queued delegation not returned", "pubkey", q.PubKey,
+			"delegator", q.Delegator, "shares", q.Shares, "err", err.Error())
+	}
+}
+
+// returnQueuedDelegation - return the coins of a queued delegation to the
+// withdraw address of the delegator
+func returnQueuedDelegation(store state.SimpleDB, transfer transferFn,
+	params Params, q QueuedBond) error {
+	returnCoins := int64(q.Shares) //currently each share is worth one coin
+	return transfer(params.HoldAccount, loadWithdrawAddress(store, q.Delegator),
+		coin.Coins{{params.AllowedBondDenom, returnCoins}})
+}
+
+// applyQueuedBond - apply a delegation or an unbond of the epoch. The
+// delegations to a candidate revoked since are returned, the unbonds are
+// limited to the shares left by a revoke or an ownership transfer. A
+// delegation fails if the ceiling of the candidate or the min delegation
+// changed since and no longer allow it.
+func applyQueuedBond(ctx sdk.Context, store state.SimpleDB, transfer transferFn,
+	params Params, hooks multiHooks, q QueuedBond) error {
+	d := deliver{
+		store:    store,
+		sender:   q.Delegator,
+		params:   params,
+		height:   ctx.BlockHeight(),
+		transfer: transfer,
+		hooks:    hooks,
+	}
+
+	candidate := loadCandidate(store, q.PubKey)
+	if q.Unbond {
+		bond := loadDelegatorBond(store, q.Delegator, q.PubKey)
+		if candidate == nil || bond == nil {
+			return nil
+		}
+		shares := q.Shares
+		if shares > bond.Shares {
+			shares = bond.Shares
+		}
+		return d.removeShares(candidate, bond, shares)
+	}
+
+	if candidate == nil || candidate.Owner.Empty() {
+		err := returnQueuedDelegation(store, transfer, params, q)
+		if err != nil {
+			return err
+		}
+		ctx.Info("Queued delegation returned", "pubkey", q.PubKey,
+			"delegator", q.Delegator, "shares", q.Shares)
+		return nil
+	}
+
+	if candidate.MaxShares > 0 && candidate.Shares+q.Shares > candidate.MaxShares {
+		return ErrCandidateMaxShares(candidate.MaxShares)
+	}
+	if !candidate.Owner.Equals(q.Delegator) {
+		var shares uint64
+		if bond := loadDelegatorBond(store, q.Delegator, q.PubKey); bond != nil {
+			shares = bond.Shares
+		}
+		if shares+q.Shares < uint64(params.MinDelegation) {
+			return ErrDelegationTooLow(params.MinDelegation)
+		}
+	}
+	d.addShares(candidate, q.Shares)
+	return nil
+}
@@ -0,0 +1,194 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+func TestEpochs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	stakeStore := stack.PrefixedStore(stakingModuleName, store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+	senders, accStore := initAccounts(2, 1000)
+	owner, delegator := senders[0], senders[1]
+	dispatch := stack.NewDispatcher(coin.NewHandler())
+
+	params := loadParams(stakeStore)
+	params.EpochLength = 10
+	saveParams(stakeStore, params)
+	deliverer := newDeliver(owner, accStore)
+	deliverer.store = stakeStore
+	deliverer.params = params
+
+	// the first block starts the first epoch
+	change, err := ProcessEpoch(stack.MockContext("testChain", 1), store, dispatch)
+	require.NoError(err)
+	assert.Zero(len(change))
+	assert.Equal(Epoch{1, 1, 11}, loadEpoch(stakeStore))
+
+	// the bonds of the epoch are queued
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(30, pk1)))
+	deliverer.sender = delegator
+	require.NoError(deliverer.delegate(newTxDelegate(20, pk1)))
+	assert.Zero(loadCandidate(stakeStore, pk1).Shares)
+	assert.Nil(loadDelegatorBond(stakeStore, delegator, pk1))
+	assert.Equal(2, len(loadQueuedBonds(stakeStore)))
+
+	// the queued shares count in the checks
+	checker := check{store: stakeStore, sender: delegator}
+	assert.Error(checker.unbond(newTxUnbond(5, pk1)))
+
+	// nothing changes until the end of the epoch
+	change, err = ProcessEpoch(stack.MockContext("testChain", 10), store, dispatch)
+	require.NoError(err)
+	assert.Zero(len(change))
+	change, err = ProcessEpoch(stack.MockContext("testChain", 11), store, dispatch)
+	require.NoError(err)
+	require.Equal(1, len(change))
+	assert.Equal(int64(50), change[0].Power)
+	assert.Equal(Epoch{2, 11, 21}, loadEpoch(stakeStore))
+	assert.Zero(len(loadQueuedBonds(stakeStore)))
+	assert.Equal(uint64(20), loadDelegatorBond(stakeStore, delegator, pk1).Shares)
+
+	// an unbond returns the coins at the end of the epoch, only the shares
+	// left can be unbonded again
+	_, err = coin.ChangeCoins(coinStore, params.HoldAccount, coin.Coins{{"fermion", 50}})
+	require.NoError(err)
+	assert.NoError(checker.unbond(newTxUnbond(15, pk1)))
+	require.NoError(deliverer.unbond(newTxUnbond(15, pk1)))
+	assert.Error(checker.unbond(newTxUnbond(15, pk1)))
+	assert.NoError(checker.unbond(newTxUnbond(5, pk1)))
+	assert.Equal(uint64(20), loadDelegatorBond(stakeStore, delegator, pk1).Shares)
+
+	// the epoch length applies from the next epoch
+	params.EpochLength = 5
+	saveParams(stakeStore, params)
+	change, err = ProcessEpoch(stack.MockContext("testChain", 21), store, dispatch)
+	require.NoError(err)
+	require.Equal(1, len(change))
+	assert.Equal(int64(35), change[0].Power)
+	assert.Equal(Epoch{3, 21, 26}, loadEpoch(stakeStore))
+	assert.Equal(uint64(5), loadDelegatorBond(stakeStore, delegator, pk1).Shares)
+	// the coin module drops the chain of the actors of this chain
+	acc, err := coin.GetAccount(coinStore, delegator.WithChain(""))
+	require.NoError(err)
+	assert.Equal(coin.Coins{{"fermion", 15}}, acc.Coins)
+}
+
+func TestQueuedBondsDropped(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	stakeStore := stack.PrefixedStore(stakingModuleName, store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+	senders, accStore := initAccounts(3, 1000)
+	owner, delegator1, delegator2 := senders[0], senders[1], senders[2]
+	dispatch := stack.NewDispatcher(coin.NewHandler())
+
+	params := loadParams(stakeStore)
+	params.EpochLength = 10
+	saveParams(stakeStore, params)
+	deliverer := newDeliver(owner, accStore)
+	deliverer.store = stakeStore
+	deliverer.params = params
+
+	_, err := ProcessEpoch(stack.MockContext("testChain", 1), store, dispatch)
+	require.NoError(err)
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(30, pk1)))
+	deliverer.sender = delegator1
+	require.NoError(deliverer.delegate(newTxDelegate(20, pk1)))
+	deliverer.sender = delegator2
+	require.NoError(deliverer.delegate(newTxDelegate(20, pk1)))
+	_, err = ProcessEpoch(stack.MockContext("testChain", 11), store, dispatch)
+	require.NoError(err)
+
+	// the hold account can only return the first unbond, the second one is
+	// dropped and the others are applied
+	_, err = coin.ChangeCoins(coinStore, params.HoldAccount, coin.Coins{{"fermion", 25}})
+	require.NoError(err)
+	deliverer.sender = delegator1
+	require.NoError(deliverer.unbond(newTxUnbond(15, pk1)))
+	deliverer.sender = delegator2
+	require.NoError(deliverer.unbond(newTxUnbond(20, pk1)))
+	deliverer.sender = owner
+	require.NoError(deliverer.unbond(newTxUnbond(10, pk1)))
+	change, err := ProcessEpoch(stack.MockContext("testChain", 21), store, dispatch)
+	require.NoError(err)
+	require.Equal(1, len(change))
+	assert.Equal(int64(45), change[0].Power)
+	assert.Zero(len(loadQueuedBonds(stakeStore)))
+
+	assert.Equal(uint64(5), loadDelegatorBond(stakeStore, delegator1, pk1).Shares)
+	assert.Equal(uint64(20), loadDelegatorBond(stakeStore, delegator2, pk1).Shares)
+	assert.Equal(uint64(20), loadDelegatorBond(stakeStore, owner, pk1).Shares)
+	assert.Equal(uint64(45), loadCandidate(stakeStore, pk1).Shares)
+	for _, acc := range []struct {
+		actor sdk.Actor
+		coins coin.Coins
+	}{
+		{delegator1, coin.Coins{{"fermion", 15}}},
+		{delegator2, nil},
+		{owner, coin.Coins{{"fermion", 10}}},
+	} {
+		account, err := coin.GetAccount(coinStore, acc.actor.WithChain(""))
+		require.NoError(err)
+		assert.Equal(acc.coins, account.Coins)
+	}
+}
+
+func TestQueuedDelegationReturned(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	stakeStore := stack.PrefixedStore(stakingModuleName, store)
+	coinStore := stack.PrefixedStore(coin.NameCoin, store)
+	senders, accStore := initAccounts(3, 1000)
+	owner, delegator1, delegator2 := senders[0], senders[1], senders[2]
+	dispatch := stack.NewDispatcher(coin.NewHandler())
+
+	params := loadParams(stakeStore)
+	params.EpochLength = 10
+	saveParams(stakeStore, params)
+	deliverer := newDeliver(owner, accStore)
+	deliverer.store = stakeStore
+	deliverer.params = params
+
+	_, err := ProcessEpoch(stack.MockContext("testChain", 1), store, dispatch)
+	require.NoError(err)
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(30, pk1)))
+	_, err = ProcessEpoch(stack.MockContext("testChain", 11), store, dispatch)
+	require.NoError(err)
+
+	// the delegations are queued, then the owner lowers the ceiling of the
+	// candidate: only the first delegation still fits at the end of the epoch
+	_, err = coin.ChangeCoins(coinStore, params.HoldAccount, coin.Coins{{"fermion", 40}})
+	require.NoError(err)
+	deliverer.sender = delegator1
+	require.NoError(deliverer.delegate(newTxDelegate(10, pk1)))
+	deliverer.sender = delegator2
+	require.NoError(deliverer.delegate(newTxDelegate(30, pk1)))
+	candidate := loadCandidate(stakeStore, pk1)
+	candidate.MaxShares = 50
+	saveCandidate(stakeStore, candidate)
+
+	_, err = ProcessEpoch(stack.MockContext("testChain", 21), store, dispatch)
+	require.NoError(err)
+	assert.Zero(len(loadQueuedBonds(stakeStore)))
+	assert.Equal(uint64(40), loadCandidate(stakeStore, pk1).Shares)
+	assert.Equal(uint64(10), loadDelegatorBond(stakeStore, delegator1, pk1).Shares)
+	assert.Nil(loadDelegatorBond(stakeStore, delegator2, pk1))
+
+	// the coins of the dropped delegation are returned from the hold account
+	account, err := coin.GetAccount(coinStore, delegator2.WithChain(""))
+	require.NoError(err)
+	assert.Equal(coin.Coins{{"fermion", 30}}, account.Coins)
+	hold, err := coin.GetAccount(coinStore, params.HoldAccount)
+	require.NoError(err)
+	assert.Equal(coin.Coins{{"fermion", 10}}, hold.Coins)
+}
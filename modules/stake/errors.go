@@ -18,11 +18,32 @@ var (
 	errBadValidatorAddr      = fmt.Errorf("Validator does not exist for that address")
 	errCandidateExistsAddr   = fmt.Errorf("Candidate already exist, cannot re-declare candidacy")
 	errMissingSignature      = fmt.Errorf("Missing signature")
+	errMultipleRoles         = fmt.Errorf("Cannot assume more than one role")
 	errBondNotNominated      = fmt.Errorf("Cannot bond to non-nominated account")
 	errNoCandidateForAddress = fmt.Errorf("Validator does not exist for that address")
 	errNoDelegatorForAddress = fmt.Errorf("Delegator does not contain validator bond")
 	errInsufficientFunds     = fmt.Errorf("Insufficient bond shares")
 	errBadRemoveValidator    = fmt.Errorf("Error removing validator")
+	errNotCandidateOwner     = fmt.Errorf("Only the owner of a candidate can perform this action")
+	errEmptyUpgradeName      = fmt.Errorf("Upgrade name cannot be empty")
+	errUpgradeScheduled      = fmt.Errorf("An upgrade is already scheduled")
+	errUpgradeNeeded         = fmt.Errorf("Upgrade needed")
+	errSamePubKey            = fmt.Errorf("New pubkey must be different from the current pubkey")
+	errOwnerEmpty            = fmt.Errorf("New owner cannot be empty")
+	errSameOwner             = fmt.Errorf("New owner must be different from the current owner")
+	errNotPendingOwner       = fmt.Errorf("Only the proposed owner of a candidate can accept the ownership")
+	errNotCandidateOperator  = fmt.Errorf("Only the owner or the operator of a candidate can perform this action")
+	errCandidatePaused       = fmt.Errorf("Candidate is already paused")
+	errCandidateNotPaused    = fmt.Errorf("Candidate is not paused")
+	errSelfBondTooLow        = fmt.Errorf("Self-bond below the minimum")
+	errDelegationTooLow      = fmt.Errorf("Delegation below the minimum")
+	errCandidatePowerCap     = fmt.Errorf("Candidate would exceed the maximum fraction of the bonded shares")
+	errCandidateMaxShares    = fmt.Errorf("Candidate would exceed its maximum shares")
+	errTooManyDelegators     = fmt.Errorf("Candidate has the maximum number of delegators")
+	errUnknownQueryPath      = fmt.Errorf("Unknown stake query path")
+	errBadQueryAddress       = fmt.Errorf("Invalid address in the query path")
+	errBadCandidatesQuery    = fmt.Errorf("Invalid candidates query")
+	errUnknownElection       = fmt.Errorf("Unknown election policy")
 
 	invalidInput = errors.CodeTypeBaseInvalidInput
 )
@@ -36,6 +57,9 @@ func ErrCandidateExistsAddr() error {
 func ErrMissingSignature() error {
 	return errors.WithCode(errMissingSignature, errors.CodeTypeUnauthorized)
 }
+func ErrMultipleRoles() error {
+	return errors.WithCode(errMultipleRoles, errors.CodeTypeUnauthorized)
+}
 func ErrBondNotNominated() error {
 	return errors.WithCode(errBondNotNominated, errors.CodeTypeBaseInvalidOutput)
 }
@@ -51,3 +75,94 @@ func ErrInsufficientFunds() error {
 func ErrBadRemoveValidator() error {
 	return errors.WithCode(errBadRemoveValidator, errors.CodeTypeInternalErr)
 }
+func ErrNotCandidateOwner() error {
+	return errors.WithCode(errNotCandidateOwner, errors.CodeTypeUnauthorized)
+}
+func ErrNotCandidateOperator() error {
+	return errors.WithCode(errNotCandidateOperator, errors.CodeTypeUnauthorized)
+}
+func ErrCandidatePaused() error {
+	return errors.WithCode(errCandidatePaused, errors.CodeTypeBaseInvalidInput)
+}
+func ErrCandidateNotPaused() error {
+	return errors.WithCode(errCandidateNotPaused, errors.CodeTypeBaseInvalidInput)
+}
+func ErrSelfBondTooLow(min int64) error {
+	msg := fmt.Sprintf("the bond of the owner must be at least %d", min)
+	return errors.WithMessage(msg, errSelfBondTooLow, errors.CodeTypeBaseInvalidInput)
+}
+func IsSelfBondTooLowErr(err error) bool {
+	return errors.IsSameError(errSelfBondTooLow, err)
+}
+func ErrDelegationTooLow(min int64) error {
+	msg := fmt.Sprintf("the bond of a delegator must be at least %d", min)
+	return errors.WithMessage(msg, errDelegationTooLow, errors.CodeTypeBaseInvalidInput)
+}
+func IsDelegationTooLowErr(err error) bool {
+	return errors.IsSameError(errDelegationTooLow, err)
+}
+func ErrCandidatePowerCap(percent int64) error {
+	msg := fmt.Sprintf("a candidate can hold at most %d%% of the bonded shares", percent)
+	return errors.WithMessage(msg, errCandidatePowerCap, errors.CodeTypeBaseInvalidInput)
+}
+func IsCandidatePowerCapErr(err error) bool {
+	return errors.IsSameError(errCandidatePowerCap, err)
+}
+func ErrCandidateMaxShares(max uint64) error {
+	msg := fmt.Sprintf("the candidate accepts at most %d shares", max)
+	return errors.WithMessage(msg, errCandidateMaxShares, errors.CodeTypeBaseInvalidInput)
+}
+func IsCandidateMaxSharesErr(err error) bool {
+	return errors.IsSameError(errCandidateMaxShares, err)
+}
+func ErrTooManyDelegators(max int64) error {
+	msg := fmt.Sprintf("a candidate can have at most %d delegators", max)
+	return errors.WithMessage(msg, errTooManyDelegators, errors.CodeTypeBaseInvalidInput)
+}
+func IsTooManyDelegatorsErr(err error) bool {
+	return errors.IsSameError(errTooManyDelegators, err)
+}
+func ErrUnknownQueryPath(path string) error {
+	return errors.WithMessage(path, errUnknownQueryPath, errors.CodeTypeUnknownRequest)
+}
+func IsUnknownQueryPathErr(err error) bool {
+	return errors.IsSameError(errUnknownQueryPath, err)
+}
+func ErrBadQueryAddress(address string) error {
+	return errors.WithMessage(address, errBadQueryAddress, errors.CodeTypeEncodingErr)
+}
+func ErrBadCandidatesQuery(msg string) error {
+	return errors.WithMessage(msg, errBadCandidatesQuery, invalidInput)
+}
+func IsBadCandidatesQueryErr(err error) bool {
+	return errors.IsSameError(errBadCandidatesQuery, err)
+}
+func ErrUnknownElection(name string) error {
+	msg := fmt.Sprintf("election policy %q is not registered in this binary", name)
+	return errors.WithMessage(msg, errUnknownElection, invalidInput)
+}
+func IsUnknownElectionErr(err error) bool {
+	return errors.IsSameError(errUnknownElection, err)
+}
+func ErrSameOwner() error {
+	return errors.WithCode(errSameOwner, errors.CodeTypeBaseInvalidInput)
+}
+func ErrNotPendingOwner() error {
+	return errors.WithCode(errNotPendingOwner, errors.CodeTypeUnauthorized)
+}
+func ErrUpgradeScheduled() error {
+	return errors.WithCode(errUpgradeScheduled, errors.CodeTypeBaseInvalidInput)
+}
+func ErrUpgradeNeeded(plan UpgradePlan) error {
+	msg := fmt.Sprintf("upgrade %q scheduled at height %d is not registered in this binary",
+		plan.Name, plan.Height)
+	return errors.WithMessage(msg, errUpgradeNeeded, errors.CodeTypeInternalErr)
+}
+func IsUpgradeNeededErr(err error) bool {
+	return errors.IsSameError(errUpgradeNeeded, err)
+}
+func ErrSchemaTooNew(version int64) error {
+	msg := fmt.Sprintf("stake store schema version %d is newer than %d supported by this binary",
+		version, SchemaVersion)
+	return errors.WithMessage(msg, errUpgradeNeeded, errors.CodeTypeInternalErr)
+}
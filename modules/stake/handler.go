@@ -2,14 +2,16 @@ package stake
 
 import (
 	"fmt"
-	"strconv"
 
+	abci "github.com/tendermint/abci/types"
+	crypto "github.com/tendermint/go-crypto"
 	"github.com/tendermint/tmlibs/log"
 
 	"github.com/cosmos/cosmos-sdk"
 	"github.com/cosmos/cosmos-sdk/errors"
 	"github.com/cosmos/cosmos-sdk/modules/auth"
 	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/modules/roles"
 	"github.com/cosmos/cosmos-sdk/stack"
 	"github.com/cosmos/cosmos-sdk/state"
 )
@@ -30,24 +32,117 @@ type delegatedProofOfStake interface {
 	editCandidacy(TxEditCandidacy) error
 	delegate(TxDelegate) error
 	unbond(TxUnbond) error
+	signalUpgrade(TxSignalUpgrade) error
+	rotateConsensusKey(TxRotateConsensusKey) error
+	proposeOwner(TxProposeOwner) error
+	acceptOwner(TxAcceptOwner) error
+	setOperator(TxSetOperator) error
+	setWithdrawAddress(TxSetWithdrawAddress) error
+	revokeCandidacy(TxRevokeCandidacy) error
+	pauseCandidacy(TxPauseCandidacy) error
+	resumeCandidacy(TxResumeCandidacy) error
+	setDelegationCap(TxSetDelegationCap) error
 }
 
-type coinSend interface {
-	transferFn(sender, receiver sdk.Actor, coins coin.Coins) error
+// CoinSend - the backend moving the bonded coins between the accounts and
+// the hold account
+type CoinSend interface {
+	TransferFn(sender, receiver sdk.Actor, coins coin.Coins) error
 }
 
+// NewCoinSend - build the CoinSend of a tx from its context, the context of
+// the txs returning coins has the permission of the hold account
+type NewCoinSend func(ctx sdk.Context, store state.SimpleDB, dispatch sdk.Deliver) CoinSend
+
 //_______________________________________________________________________
 
 // Handler - the transaction processing handler
 type Handler struct {
 	stack.PassInitValidate
+	params      Params
+	newCoinSend NewCoinSend
+	hooks       multiHooks
 }
 
 var _ stack.Dispatchable = Handler{} // enforce interface at compile time
 
-// NewHandler returns a new Handler with the default Params
-func NewHandler() Handler {
-	return Handler{}
+// Option - configure the Handler, to embed the stake module in an app
+type Option func(*Handler)
+
+// WithHoldAccount - the address of the account holding the bonded coins,
+// the account belongs to the stake module
+func WithHoldAccount(address []byte) Option {
+	return func(h *Handler) {
+		h.params.HoldAccount = sdk.NewActor(stakingModuleName, address)
+	}
+}
+
+// WithBondDenom - the denomination of the coins which can be bonded
+func WithBondDenom(denom string) Option {
+	return func(h *Handler) {
+		h.params.AllowedBondDenom = denom
+	}
+}
+
+// WithParams - the params of the chain, the options applied after it
+// override them. The params of the options are saved by the first stake
+// option of the genesis, a genesis without any runs on the default params.
+func WithParams(params Params) Option {
+	return func(h *Handler) {
+		h.params = params
+	}
+}
+
+// WithCoinSend - move the coins of the txs with a custom backend, it also
+// returns the coins of the bonds queued during an epoch at the next epoch
+func WithCoinSend(newCoinSend NewCoinSend) Option {
+	return func(h *Handler) {
+		h.newCoinSend = newCoinSend
+	}
+}
+
+// WithHooks - call the hooks on the staking events, the hooks of several
+// options are all called in the order of the options
+func WithHooks(hooks ...Hooks) Option {
+	return func(h *Handler) {
+		h.hooks = append(h.hooks, hooks...)
+	}
+}
+
+// NewHandler returns a new Handler with the default Params, changed by the
+// options. The name of the module is always "stake": the txs are routed to
+// the module by the name their type is registered with.
+func NewHandler(options ...Option) Handler {
+	h := Handler{
+		params: defaultParams(),
+		newCoinSend: func(ctx sdk.Context, store state.SimpleDB, dispatch sdk.Deliver) CoinSend {
+			return coinSender{
+				store:    store,
+				dispatch: dispatch,
+				ctx:      ctx,
+			}
+		},
+	}
+	for _, option := range options {
+		option(&h)
+	}
+	return h
+}
+
+// initParams - save the params of the handler and the version of the layout
+// at the first genesis option of the module, the genesis options then change
+// the params
+func (h Handler) initParams(store state.SimpleDB) error {
+	if store.Has(ParamKey) {
+		return nil
+	}
+	if _, ok := electionPolicies[h.params.ElectionPolicy]; !ok {
+		return ErrUnknownElection(h.params.ElectionPolicy)
+	}
+	// the genesis writes a new store in the current layout
+	saveSchemaVersion(store, SchemaVersion)
+	saveParams(store, h.params)
+	return nil
 }
 
 // Name - return stake namespace
@@ -65,41 +160,36 @@ func (h Handler) InitState(l log.Logger, store state.SimpleDB,
 }
 
 // separated for testing
-func (Handler) initState(module, key, value string, store state.SimpleDB) error {
+func (h Handler) initState(module, key, value string, store state.SimpleDB) error {
 	if module != stakingModuleName {
 		return errors.ErrUnknownModule(module)
 	}
 
-	params := loadParams(store)
-	switch key {
-	case "allowed_bond_denom":
-		params.AllowedBondDenom = value
-	case "max_vals",
-		"gas_bond",
-		"gas_unbond":
-
-		// TODO: enforce non-negative integers in input
-		i, err := strconv.Atoi(value)
-		if err != nil {
-			return fmt.Errorf("input must be integer, Error: %v", err.Error())
-		}
-
-		switch key {
-		case "max_vals":
-			params.MaxVals = uint16(i)
-		case "gas_bond":
-			params.GasDelegate = int64(i)
-		case "gas_unbound":
-			params.GasUnbond = int64(i)
-		}
-	default:
-		return errors.ErrUnknownKey(key)
+	err := h.initParams(store)
+	if err != nil {
+		return err
 	}
+	return SetParam(store, key, value)
+}
 
+// SetParam - validate and save a single staking parameter. The same rules
+// apply at genesis and to the parameter changes passed by governance.
+func SetParam(store state.SimpleDB, key, value string) error {
+	params := loadParams(store)
+	err := params.set(key, value)
+	if err != nil {
+		return err
+	}
 	saveParams(store, params)
 	return nil
 }
 
+// ValidateParam - check that a parameter change would be accepted by SetParam
+// on the current params of the store
+func ValidateParam(store state.SimpleDB, key, value string) error {
+	return loadParams(store).ValidateParam(key, value)
+}
+
 // CheckTx checks if the tx is properly structured
 func (h Handler) CheckTx(ctx sdk.Context, store state.SimpleDB,
 	tx sdk.Tx, _ sdk.Checker) (res sdk.CheckResult, err error) {
@@ -137,6 +227,36 @@ func (h Handler) CheckTx(ctx sdk.Context, store state.SimpleDB,
 	case TxUnbond:
 		return sdk.NewCheck(params.GasUnbond, ""),
 			checker.unbond(txInner)
+	case TxSignalUpgrade:
+		return sdk.NewCheck(params.GasSignalUpgrade, ""),
+			checker.signalUpgrade(txInner)
+	case TxRotateConsensusKey:
+		return sdk.NewCheck(params.GasRotateConsensusKey, ""),
+			checker.rotateConsensusKey(txInner)
+	case TxProposeOwner:
+		return sdk.NewCheck(params.GasProposeOwner, ""),
+			checker.proposeOwner(txInner)
+	case TxAcceptOwner:
+		return sdk.NewCheck(params.GasAcceptOwner, ""),
+			checker.acceptOwner(txInner)
+	case TxSetOperator:
+		return sdk.NewCheck(params.GasSetOperator, ""),
+			checker.setOperator(txInner)
+	case TxSetWithdrawAddress:
+		return sdk.NewCheck(params.GasSetWithdrawAddress, ""),
+			checker.setWithdrawAddress(txInner)
+	case TxRevokeCandidacy:
+		return sdk.NewCheck(revokeGas(store, params, txInner.PubKey), ""),
+			checker.revokeCandidacy(txInner)
+	case TxPauseCandidacy:
+		return sdk.NewCheck(params.GasPauseCandidacy, ""),
+			checker.pauseCandidacy(txInner)
+	case TxResumeCandidacy:
+		return sdk.NewCheck(params.GasResumeCandidacy, ""),
+			checker.resumeCandidacy(txInner)
+	case TxSetDelegationCap:
+		return sdk.NewCheck(params.GasSetDelegationCap, ""),
+			checker.setDelegationCap(txInner)
 	}
 
 	return res, errors.ErrUnknownTxType(tx)
@@ -160,14 +280,12 @@ func (h Handler) DeliverTx(ctx sdk.Context, store state.SimpleDB,
 
 	params := loadParams(store)
 	deliverer := deliver{
-		store:  store,
-		sender: sender,
-		params: params,
-		transfer: coinSender{
-			store:    store,
-			dispatch: dispatch,
-			ctx:      ctx,
-		}.transferFn,
+		store:    store,
+		sender:   sender,
+		params:   params,
+		height:   ctx.BlockHeight(),
+		transfer: h.newCoinSend(ctx, store, dispatch).TransferFn,
+		hooks:    h.hooks,
 	}
 
 	// Run the transaction
@@ -183,21 +301,76 @@ func (h Handler) DeliverTx(ctx sdk.Context, store state.SimpleDB,
 		return res, deliverer.delegate(_tx)
 	case TxUnbond:
 		//context with hold account permissions
-		params := loadParams(store)
 		res.GasUsed = params.GasUnbond
 		ctx2 := ctx.WithPermissions(params.HoldAccount)
-		deliverer.transfer = coinSender{
-			store:    store,
-			dispatch: dispatch,
-			ctx:      ctx2,
-		}.transferFn
+		deliverer.transfer = h.newCoinSend(ctx2, store, dispatch).TransferFn
 		return res, deliverer.unbond(_tx)
+	case TxSignalUpgrade:
+		res.GasUsed = params.GasSignalUpgrade
+		return res, deliverer.signalUpgrade(_tx)
+	case TxRotateConsensusKey:
+		res.GasUsed = params.GasRotateConsensusKey
+		return res, deliverer.rotateConsensusKey(_tx)
+	case TxProposeOwner:
+		res.GasUsed = params.GasProposeOwner
+		return res, deliverer.proposeOwner(_tx)
+	case TxAcceptOwner:
+		res.GasUsed = params.GasAcceptOwner
+		return res, deliverer.acceptOwner(_tx)
+	case TxSetOperator:
+		res.GasUsed = params.GasSetOperator
+		return res, deliverer.setOperator(_tx)
+	case TxSetWithdrawAddress:
+		res.GasUsed = params.GasSetWithdrawAddress
+		return res, deliverer.setWithdrawAddress(_tx)
+	case TxRevokeCandidacy:
+		//context with hold account permissions
+		res.GasUsed = revokeGas(store, params, _tx.PubKey)
+		ctx2 := ctx.WithPermissions(params.HoldAccount)
+		deliverer.transfer = h.newCoinSend(ctx2, store, dispatch).TransferFn
+		res.Tags = revokeTags(ctx, store, _tx.PubKey)
+		return res, deliverer.revokeCandidacy(_tx)
+	case TxPauseCandidacy:
+		res.GasUsed = params.GasPauseCandidacy
+		return res, deliverer.pauseCandidacy(_tx)
+	case TxResumeCandidacy:
+		res.GasUsed = params.GasResumeCandidacy
+		return res, deliverer.resumeCandidacy(_tx)
+	case TxSetDelegationCap:
+		res.GasUsed = params.GasSetDelegationCap
+		return res, deliverer.setDelegationCap(_tx)
 	}
 	return
 }
 
-// get the sender from the ctx and ensure it matches the tx pubkey
+// gas of a revoke tx, it returns the bond of each delegator
+func revokeGas(store state.SimpleDB, params Params, pubKey crypto.PubKey) int64 {
+	return params.GasRevokeCandidacy + params.GasUnbond*loadDelegatorCount(store, pubKey)
+}
+
+// tags of a revoke tx, the delegators whose bonds are returned can search for
+// the revoke with stake.delegator='<address>'
+func revokeTags(ctx sdk.Context, store state.SimpleDB, pubKey crypto.PubKey) []*abci.KVPair {
+	tags := []*abci.KVPair{abci.KVPairInt("height", ctx.BlockHeight())}
+	for _, delegator := range loadCandidateDelegators(store, pubKey) {
+		tags = append(tags, abci.KVPairString("stake.delegator", delegator.String()))
+	}
+	return tags
+}
+
+// get the sender from the ctx, the role assumed through the roles middleware
+// if any, otherwise the single signer of the tx. A role lets a multisig own
+// candidates and bonds.
 func getTxSender(ctx sdk.Context) (sender sdk.Actor, err error) {
+	assumed := ctx.GetPermissions("", roles.NameRole)
+	switch len(assumed) {
+	case 0:
+	case 1:
+		return assumed[0], nil
+	default:
+		return sender, ErrMultipleRoles()
+	}
+
 	senders := ctx.GetPermissions("", auth.NameSigs)
 	if len(senders) != 1 {
 		return sender, ErrMissingSignature()
@@ -213,9 +386,10 @@ type coinSender struct {
 	ctx      sdk.Context
 }
 
-var _ coinSend = coinSender{} // enforce interface at compile time
+var _ CoinSend = coinSender{} // enforce interface at compile time
 
-func (c coinSender) transferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
+// TransferFn - send the coins with the coin module
+func (c coinSender) TransferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
 	send := coin.NewSendOneTx(sender, receiver, coins)
 
 	// If the deduction fails (too high), abort the command
@@ -242,6 +416,14 @@ func (c check) declareCandidacy(tx TxDeclareCandidacy) error {
 			candidate.PubKey, candidate.Owner)
 	}
 
+	params := loadParams(c.store)
+	if tx.Bond.Amount < params.MinSelfBond {
+		return ErrSelfBondTooLow(params.MinSelfBond)
+	}
+	err := checkPowerFraction(c.store, params, loadQueuedBonds(c.store), 0, uint64(tx.Bond.Amount))
+	if err != nil {
+		return err
+	}
 	return checkDenom(tx.BondUpdate, c.store)
 }
 
@@ -252,6 +434,9 @@ func (c check) editCandidacy(tx TxEditCandidacy) error {
 	if candidate == nil { // does PubKey exist
 		return fmt.Errorf("cannot delegate to non-existant PubKey %v", tx.PubKey)
 	}
+	if !candidate.canOperate(c.sender) {
+		return ErrNotCandidateOperator()
+	}
 	return nil
 }
 
@@ -261,6 +446,44 @@ func (c check) delegate(tx TxDelegate) error {
 	if candidate == nil { // does PubKey exist
 		return fmt.Errorf("cannot delegate to non-existant PubKey %v", tx.PubKey)
 	}
+
+	// the shares of the sender and of the candidate at the end of the
+	// epoch, with the delegations and unbonds queued during the epoch
+	params := loadParams(c.store)
+	queue := loadQueuedBonds(c.store)
+	var shares uint64
+	bond := loadDelegatorBond(c.store, c.sender, tx.PubKey)
+	if bond != nil {
+		shares = bond.Shares
+	}
+	delegated, unbonded := queue.shares(tx.PubKey, c.sender)
+	shares = sharesAfterEpoch(shares, delegated, unbonded)
+	delegated, unbonded = queue.shares(tx.PubKey, sdk.Actor{})
+	candidateShares := sharesAfterEpoch(candidate.Shares, delegated, unbonded)
+
+	// the owner can top up its self-bond by any amount
+	if !candidate.Owner.Equals(c.sender) {
+		if shares+uint64(tx.Bond.Amount) < uint64(params.MinDelegation) {
+			return ErrDelegationTooLow(params.MinDelegation)
+		}
+	}
+
+	// the caps on the shares of the candidate and on its delegators
+	amount := uint64(tx.Bond.Amount)
+	if candidate.MaxShares > 0 && candidateShares+amount > candidate.MaxShares {
+		return ErrCandidateMaxShares(candidate.MaxShares)
+	}
+	err := checkPowerFraction(c.store, params, queue, candidateShares, amount)
+	if err != nil {
+		return err
+	}
+	if params.MaxDelegatorsPerCandidate > 0 && bond == nil && !queue.delegates(tx.PubKey, c.sender) {
+		count := loadDelegatorCount(c.store, tx.PubKey)
+		count += queue.newDelegators(c.store, tx.PubKey)
+		if count >= params.MaxDelegatorsPerCandidate {
+			return ErrTooManyDelegators(params.MaxDelegatorsPerCandidate)
+		}
+	}
 	return checkDenom(tx.BondUpdate, c.store)
 }
 
@@ -268,9 +491,183 @@ func (c check) unbond(tx TxUnbond) error {
 
 	// check if have enough shares to unbond
 	bond := loadDelegatorBond(c.store, c.sender, tx.PubKey)
-	if bond.Shares < tx.Shares {
+	if bond == nil {
+		return ErrNoDelegatorForAddress()
+	}
+	// the shares unbonded during the epoch are no longer available
+	queue := loadQueuedBonds(c.store)
+	delegated, unbonded := queue.shares(tx.PubKey, c.sender)
+	available := sharesAfterEpoch(bond.Shares, 0, unbonded)
+	if available < tx.Shares {
 		return fmt.Errorf("not enough bond shares to unbond, have %v, trying to unbond %v",
-			bond.Shares, tx.Shares)
+			available, tx.Shares)
+	}
+
+	// a partial unbond must leave at least the minimum bond
+	left := available + delegated - tx.Shares
+	if left == 0 {
+		return nil
+	}
+	params := loadParams(c.store)
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate != nil && candidate.Owner.Equals(c.sender) {
+		if left < uint64(params.MinSelfBond) {
+			return ErrSelfBondTooLow(params.MinSelfBond)
+		}
+	} else if left < uint64(params.MinDelegation) {
+		return ErrDelegationTooLow(params.MinDelegation)
+	}
+	return nil
+}
+
+func (c check) signalUpgrade(tx TxSignalUpgrade) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.canOperate(c.sender) {
+		return ErrNotCandidateOperator()
+	}
+	if loadUpgradePlan(c.store) != nil {
+		return ErrUpgradeScheduled()
+	}
+	return nil
+}
+
+func (c check) rotateConsensusKey(tx TxRotateConsensusKey) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.Owner.Equals(c.sender) {
+		return ErrNotCandidateOwner()
+	}
+	if loadCandidate(c.store, tx.NewPubKey) != nil {
+		return ErrCandidateExistsAddr()
+	}
+	return nil
+}
+
+func (c check) proposeOwner(tx TxProposeOwner) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.Owner.Equals(c.sender) {
+		return ErrNotCandidateOwner()
+	}
+	if candidate.Owner.Equals(tx.NewOwner) {
+		return ErrSameOwner()
+	}
+	return nil
+}
+
+func (c check) acceptOwner(tx TxAcceptOwner) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if candidate.PendingOwner == nil || !candidate.PendingOwner.Equals(c.sender) {
+		return ErrNotPendingOwner()
+	}
+	return nil
+}
+
+func (c check) setOperator(tx TxSetOperator) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.Owner.Equals(c.sender) {
+		return ErrNotCandidateOwner()
+	}
+	return nil
+}
+
+func (c check) setWithdrawAddress(tx TxSetWithdrawAddress) error {
+	return nil
+}
+
+func (c check) revokeCandidacy(tx TxRevokeCandidacy) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.Owner.Equals(c.sender) {
+		return ErrNotCandidateOwner()
+	}
+	return nil
+}
+
+func (c check) pauseCandidacy(tx TxPauseCandidacy) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.canOperate(c.sender) {
+		return ErrNotCandidateOperator()
+	}
+	if candidate.paused() {
+		return ErrCandidatePaused()
+	}
+	return nil
+}
+
+func (c check) resumeCandidacy(tx TxResumeCandidacy) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.canOperate(c.sender) {
+		return ErrNotCandidateOperator()
+	}
+	if !candidate.paused() {
+		return ErrCandidateNotPaused()
+	}
+	return nil
+}
+
+func (c check) setDelegationCap(tx TxSetDelegationCap) error {
+
+	candidate := loadCandidate(c.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if !candidate.canOperate(c.sender) {
+		return ErrNotCandidateOperator()
+	}
+	return nil
+}
+
+// checkPowerFraction - a bond of amount to a candidate holding shares must
+// leave it at most MaxCandidatePowerFraction percent of all the bonded
+// shares, with the bonds queued during the epoch. The first bonds of the
+// chain are not capped, the first candidate holds all the shares.
+func checkPowerFraction(store state.SimpleDB, params Params, queue QueuedBonds,
+	shares, amount uint64) error {
+	if params.MaxCandidatePowerFraction == 0 {
+		return nil
+	}
+	var total uint64
+	for _, c := range loadCandidates(store) {
+		total += c.Shares
+	}
+	delegated, unbonded := queue.shares(crypto.PubKey{}, sdk.Actor{})
+	total = sharesAfterEpoch(total, delegated, unbonded)
+	if total == 0 {
+		return nil
+	}
+	fraction := uint64(params.MaxCandidatePowerFraction)
+	if (shares+amount)*100 > fraction*(total+amount) {
+		return ErrCandidatePowerCap(params.MaxCandidatePowerFraction)
 	}
 	return nil
 }
@@ -288,7 +685,9 @@ type deliver struct {
 	store    state.SimpleDB
 	sender   sdk.Actor
 	params   Params
+	height   int64
 	transfer transferFn
+	hooks    multiHooks
 }
 
 type transferFn func(sender, receiver sdk.Actor, coins coin.Coins) error
@@ -307,6 +706,7 @@ func (d deliver) declareCandidacy(tx TxDeclareCandidacy) error {
 	candidate := NewCandidate(tx.PubKey, d.sender)
 	candidate.Description = tx.Description // add the description parameters
 	saveCandidate(d.store, candidate)
+	d.hooks.queueEvent(d.store, hookEvent{Kind: eventCandidateCreated, Candidate: candidate})
 
 	// move coins from the d.sender account to a (self-bond) delegator account
 	// the candidate account will be updated automatically here
@@ -340,6 +740,7 @@ func (d deliver) editCandidacy(tx TxEditCandidacy) error {
 	}
 
 	saveCandidate(d.store, candidate)
+	d.hooks.queueEvent(d.store, hookEvent{Kind: eventCandidateEdited, Candidate: candidate})
 	return nil
 }
 
@@ -364,25 +765,41 @@ func (d deliver) delegate(tx TxDelegate) error {
 	//query.GetParsed(key, &acc, query.GetHeight(), false)
 	//panic(fmt.Sprintf("debug acc: %v\n", acc))
 
+	// within an epoch the shares are added at its end
+	bondAmount := uint64(tx.Bond.Amount) // XXX: checked for underflow in ValidateBasic
+	if d.params.EpochLength > 1 {
+		queueBond(d.store, QueuedBond{
+			Delegator: d.sender,
+			PubKey:    tx.PubKey,
+			Shares:    bondAmount,
+		})
+		return nil
+	}
+	d.addShares(candidate, bondAmount)
+	return nil
+}
+
+// addShares - add the delegated shares to the bond of the sender and to the
+// candidate
+func (d deliver) addShares(candidate *Candidate, shares uint64) {
+
 	// Get or create the delegator bond
-	bond := loadDelegatorBond(d.store, d.sender, tx.PubKey)
+	bond := loadDelegatorBond(d.store, d.sender, candidate.PubKey)
 	if bond == nil {
 		bond = &DelegatorBond{
-			PubKey: tx.PubKey,
+			PubKey: candidate.PubKey,
 			Shares: 0,
 		}
 	}
 
 	// Add shares to delegator bond and candidate
-	bondAmount := uint64(tx.Bond.Amount) // XXX: checked for underflow in ValidateBasic
-	bond.Shares += bondAmount
-	candidate.Shares += bondAmount
+	bond.Shares += shares
+	candidate.Shares += shares
 
 	// Save to d.store
 	saveCandidate(d.store, candidate)
 	saveDelegatorBond(d.store, d.sender, bond)
-
-	return nil
+	d.hooks.queueEvent(d.store, hookEvent{Kind: eventDelegationChanged, Delegator: d.sender, Bond: bond})
 }
 
 func (d deliver) unbond(tx TxUnbond) error {
@@ -399,11 +816,29 @@ func (d deliver) unbond(tx TxUnbond) error {
 		return ErrNoCandidateForAddress()
 	}
 
-	// subtract bond tokens from bond
 	if bond.Shares < tx.Shares {
 		return ErrInsufficientFunds()
 	}
-	bond.Shares -= tx.Shares
+
+	// within an epoch the shares are unbonded at its end
+	if d.params.EpochLength > 1 {
+		queueBond(d.store, QueuedBond{
+			Delegator: d.sender,
+			PubKey:    tx.PubKey,
+			Shares:    tx.Shares,
+			Unbond:    true,
+		})
+		return nil
+	}
+	return d.removeShares(candidate, bond, tx.Shares)
+}
+
+// removeShares - remove the unbonded shares from the bond of the sender and
+// from the candidate, and return their coins
+func (d deliver) removeShares(candidate *Candidate, bond *DelegatorBond, shares uint64) error {
+
+	// subtract bond tokens from bond
+	bond.Shares -= shares
 
 	if bond.Shares == 0 {
 
@@ -414,22 +849,216 @@ func (d deliver) unbond(tx TxUnbond) error {
 		}
 
 		// remove the bond
-		removeDelegatorBond(d.store, d.sender, tx.PubKey)
+		removeDelegatorBond(d.store, d.sender, candidate.PubKey)
 	} else {
 		saveDelegatorBond(d.store, d.sender, bond)
 	}
 
 	// deduct shares from the candidate
-	candidate.Shares -= tx.Shares
+	candidate.Shares -= shares
 	if candidate.Shares == 0 {
-		removeCandidate(d.store, tx.PubKey)
+		saveRemovedValidator(d.store, candidate)
+		removeCandidate(d.store, candidate.PubKey)
 	} else {
 		saveCandidate(d.store, candidate)
 	}
+	d.hooks.queueEvent(d.store, hookEvent{Kind: eventUnbonded, Delegator: d.sender, Bond: bond, Shares: shares})
 
-	// transfer coins back to account
-	txShares := int64(tx.Shares) // XXX: watch overflow
-	returnCoins := txShares      //currently each share is worth one coin
-	return d.transfer(d.params.HoldAccount, d.sender,
+	// transfer coins back to the withdraw address of the delegator
+	txShares := int64(shares) // XXX: watch overflow
+	returnCoins := txShares   //currently each share is worth one coin
+	return d.transfer(d.params.HoldAccount, loadWithdrawAddress(d.store, d.sender),
 		coin.Coins{{d.params.AllowedBondDenom, returnCoins}})
 }
+
+func (d deliver) signalUpgrade(tx TxSignalUpgrade) error {
+
+	// a new signal replaces any previous signal of the candidate
+	saveUpgradeSignal(d.store, tx.PubKey, tx.Name)
+	return nil
+}
+
+func (d deliver) rotateConsensusKey(tx TxRotateConsensusKey) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	if loadCandidate(d.store, tx.NewPubKey) != nil {
+		return ErrCandidateExistsAddr()
+	}
+
+	// re-key the delegator bonds
+	for _, delegator := range loadCandidateDelegators(d.store, tx.PubKey) {
+		bond := loadDelegatorBond(d.store, delegator, tx.PubKey)
+		removeDelegatorBond(d.store, delegator, tx.PubKey)
+		bond.PubKey = tx.NewPubKey
+		saveDelegatorBond(d.store, delegator, bond)
+	}
+
+	// re-key the delegations and unbonds of the epoch
+	queue := loadQueuedBonds(d.store)
+	for i := range queue {
+		if queue[i].PubKey.Equals(tx.PubKey) {
+			queue[i].PubKey = tx.NewPubKey
+		}
+	}
+	if len(queue) > 0 {
+		saveQueuedBonds(d.store, queue)
+	}
+
+	// re-key the candidate, keeping its upgrade signal
+	signal := loadUpgradeSignal(d.store, tx.PubKey)
+	removeCandidate(d.store, tx.PubKey)
+	candidate.PubKey = tx.NewPubKey
+	saveCandidate(d.store, candidate)
+	if signal != "" {
+		saveUpgradeSignal(d.store, tx.NewPubKey, signal)
+	}
+
+	// the validator set update removes the old key
+	saveKeyRotation(d.store, tx.PubKey, tx.NewPubKey)
+	return nil
+}
+
+func (d deliver) proposeOwner(tx TxProposeOwner) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+
+	// a new proposal replaces any previous one
+	newOwner := tx.NewOwner
+	candidate.PendingOwner = &newOwner
+	saveCandidate(d.store, candidate)
+	return nil
+}
+
+func (d deliver) acceptOwner(tx TxAcceptOwner) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+	oldOwner := candidate.Owner
+
+	// the self-bond moves to the new owner, added to any bond it already has
+	selfBond := loadDelegatorBond(d.store, oldOwner, tx.PubKey)
+	if selfBond != nil {
+		bond := loadDelegatorBond(d.store, d.sender, tx.PubKey)
+		if bond == nil {
+			bond = &DelegatorBond{
+				PubKey: tx.PubKey,
+				Shares: 0,
+			}
+		}
+		bond.Shares += selfBond.Shares
+		removeDelegatorBond(d.store, oldOwner, tx.PubKey)
+		saveDelegatorBond(d.store, d.sender, bond)
+	}
+
+	candidate.Owner = d.sender
+	candidate.PendingOwner = nil
+	candidate.OwnerChanges = append(candidate.OwnerChanges,
+		OwnerChange{d.height, oldOwner, d.sender})
+	saveCandidate(d.store, candidate)
+	if selfBond != nil {
+		d.hooks.queueEvent(d.store, hookEvent{Kind: eventDelegationChanged, Delegator: oldOwner,
+			Bond: &DelegatorBond{PubKey: tx.PubKey}})
+		d.hooks.queueEvent(d.store, hookEvent{Kind: eventDelegationChanged, Delegator: d.sender,
+			Bond: loadDelegatorBond(d.store, d.sender, tx.PubKey)})
+	}
+	return nil
+}
+
+func (d deliver) setOperator(tx TxSetOperator) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+
+	candidate.Operator = nil
+	if !tx.Operator.Empty() {
+		operator := tx.Operator
+		candidate.Operator = &operator
+	}
+	saveCandidate(d.store, candidate)
+	return nil
+}
+
+func (d deliver) setWithdrawAddress(tx TxSetWithdrawAddress) error {
+	if tx.Address.Empty() || tx.Address.Equals(d.sender) {
+		removeWithdrawAddress(d.store, d.sender)
+		return nil
+	}
+	saveWithdrawAddress(d.store, d.sender, tx.Address)
+	return nil
+}
+
+func (d deliver) revokeCandidacy(tx TxRevokeCandidacy) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+
+	// return every bond to the withdraw address of its delegator
+	for _, delegator := range loadCandidateDelegators(d.store, tx.PubKey) {
+		bond := loadDelegatorBond(d.store, delegator, tx.PubKey)
+		removeDelegatorBond(d.store, delegator, tx.PubKey)
+		shares := bond.Shares
+		bond.Shares = 0
+		d.hooks.queueEvent(d.store, hookEvent{Kind: eventUnbonded, Delegator: delegator, Bond: bond, Shares: shares})
+
+		returnCoins := int64(shares) //currently each share is worth one coin
+		err := d.transfer(d.params.HoldAccount, loadWithdrawAddress(d.store, delegator),
+			coin.Coins{{d.params.AllowedBondDenom, returnCoins}})
+		if err != nil {
+			return err
+		}
+	}
+
+	// the validator set update removes the validator
+	saveRemovedValidator(d.store, candidate)
+	removeCandidate(d.store, tx.PubKey)
+	return nil
+}
+
+func (d deliver) pauseCandidacy(tx TxPauseCandidacy) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+
+	// the validator set update of the block gives its slot to the next candidate
+	candidate.PausedHeight = d.height
+	saveCandidate(d.store, candidate)
+	return nil
+}
+
+func (d deliver) resumeCandidacy(tx TxResumeCandidacy) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+
+	candidate.PausedHeight = 0
+	saveCandidate(d.store, candidate)
+	return nil
+}
+
+func (d deliver) setDelegationCap(tx TxSetDelegationCap) error {
+
+	candidate := loadCandidate(d.store, tx.PubKey)
+	if candidate == nil {
+		return ErrNoCandidateForAddress()
+	}
+
+	candidate.MaxShares = tx.MaxShares
+	saveCandidate(d.store, candidate)
+	return nil
+}
@@ -1,6 +1,7 @@
 package stake
 
 import (
+	"bytes"
 	"encoding/hex"
 	"testing"
 
@@ -10,7 +11,10 @@ import (
 	crypto "github.com/tendermint/go-crypto"
 
 	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/auth"
 	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/modules/roles"
+	"github.com/cosmos/cosmos-sdk/stack"
 	"github.com/cosmos/cosmos-sdk/state"
 )
 
@@ -20,9 +24,9 @@ type testCoinSender struct {
 	store map[string]int64
 }
 
-var _ coinSend = testCoinSender{} // enforce interface at compile time
+var _ CoinSend = testCoinSender{} // enforce interface at compile time
 
-func (c testCoinSender) transferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
+func (c testCoinSender) TransferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
 	c.store[string(sender.Address)] -= int64(coins[0].Amount)
 	c.store[string(receiver.Address)] += int64(coins[0].Amount)
 	return nil
@@ -69,7 +73,7 @@ func newDeliver(sender sdk.Actor, accStore map[string]int64) deliver {
 		store:    store,
 		sender:   sender,
 		params:   loadParams(store),
-		transfer: testCoinSender{accStore}.transferFn,
+		transfer: testCoinSender{accStore}.TransferFn,
 	}
 }
 
@@ -323,3 +327,577 @@ func TestVoidCandidacy(t *testing.T) {
 	assert.NoError(got, "expected ok, got %v", got)
 
 }
+
+func TestSetParam(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+
+	require.NoError(SetParam(store, "max_vals", "7"))
+	require.NoError(SetParam(store, "gas_unbond", "3"))
+	require.NoError(SetParam(store, "allowed_bond_denom", "atom"))
+	params := loadParams(store)
+	assert.Equal(uint16(7), params.MaxVals)
+	assert.Equal(int64(3), params.GasUnbond)
+	assert.Equal("atom", params.AllowedBondDenom)
+
+	// a valid change is validated on the current params without saving it
+	require.NoError(ValidateParam(store, "max_vals", "9"))
+	assert.Equal(params, loadParams(store))
+
+	// invalid values are rejected and leave the params untouched
+	cases := []struct{ key, value string }{
+		{"max_vals", "-1"},
+		{"max_vals", "65536"},
+		{"gas_bond", "ten"},
+		{"allowed_bond_denom", ""},
+		{"hold_account", "1234"},
+		{"election_policy", "lottery"},
+		{"epoch_length", "0"},
+	}
+	for _, tc := range cases {
+		assert.Error(ValidateParam(store, tc.key, tc.value), "%v", tc)
+		assert.Error(SetParam(store, tc.key, tc.value), "%v", tc)
+	}
+	assert.Equal(params, loadParams(store))
+}
+
+func TestTxGas(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	owner := auth.SigPerm([]byte("owner"))
+	accStore[string(owner.Address)] = 1000
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	for _, delegator := range senders {
+		deliverer.sender = delegator
+		require.NoError(deliverer.delegate(newTxDelegate(10, pk1)))
+	}
+	deliverer.sender = senders[0]
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk2)))
+
+	require.NoError(SetParam(store, "gas_set_operator", "7"))
+	require.NoError(SetParam(store, "gas_revoke_candidacy", "5"))
+	require.NoError(SetParam(store, "gas_unbond", "3"))
+	ctx := stack.MockContext("testChain", 1).WithPermissions(owner)
+
+	// each tx has its own gas cost
+	res, err := NewHandler().CheckTx(ctx, store, NewTxSetOperator(pk1, senders[0]), nil)
+	require.NoError(err)
+	assert.Equal(int64(7), res.GasAllocated)
+
+	// a revoke pays for the bond of each delegator it returns, the bonds to
+	// other candidates are not counted
+	res, err = NewHandler().CheckTx(ctx, store, NewTxRevokeCandidacy(pk1), nil)
+	require.NoError(err)
+	assert.Equal(int64(5+3*3), res.GasAllocated)
+	assert.Equal(int64(1), loadDelegatorCount(store, pk2))
+}
+
+func TestRotateConsensusKey(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(3, 1000)
+	owner, delegator := senders[0], senders[1]
+
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	deliverer.sender = senders[2]
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(5, pk3)))
+	deliverer.sender = delegator
+	require.NoError(deliverer.delegate(newTxDelegate(20, pk1)))
+	_, err := UpdateValidatorSet(store)
+	require.NoError(err)
+
+	// only the owner can rotate, and not to the key of another candidate
+	checker := check{store: store, sender: delegator}
+	assert.Error(checker.rotateConsensusKey(TxRotateConsensusKey{pk1, pk2}))
+	checker.sender = owner
+	assert.Error(checker.rotateConsensusKey(TxRotateConsensusKey{pk1, pk3}))
+	assert.NoError(checker.rotateConsensusKey(TxRotateConsensusKey{pk1, pk2}))
+
+	deliverer.sender = owner
+	require.NoError(deliverer.rotateConsensusKey(TxRotateConsensusKey{pk1, pk2}))
+
+	// the candidate and all its bonds moved to the new key
+	assert.Nil(loadCandidate(store, pk1))
+	candidate := loadCandidate(store, pk2)
+	require.NotNil(candidate)
+	assert.Equal(owner, candidate.Owner)
+	assert.Equal(uint64(30), candidate.Shares)
+	for _, d := range []sdk.Actor{owner, delegator} {
+		assert.Nil(loadDelegatorBond(store, d, pk1))
+		assert.NotNil(loadDelegatorBond(store, d, pk2))
+		assert.Equal([]crypto.PubKey{pk2}, loadDelegatorCandidates(store, d))
+	}
+	assert.Equal(uint64(10), loadDelegatorBond(store, owner, pk2).Shares)
+	assert.Equal(uint64(20), loadDelegatorBond(store, delegator, pk2).Shares)
+
+	// the old key is removed and the new key gets the full power
+	change, err := UpdateValidatorSet(store)
+	require.NoError(err)
+	require.Equal(2, len(change), "%v", change)
+	for _, c := range change {
+		switch {
+		case bytes.Equal(c.PubKey, pk1.Bytes()):
+			assert.Equal(int64(0), c.Power)
+		case bytes.Equal(c.PubKey, pk2.Bytes()):
+			assert.Equal(int64(30), c.Power)
+		default:
+			t.Errorf("unexpected change %v", c)
+		}
+	}
+
+	// the rotation is only reported once
+	change, err = UpdateValidatorSet(store)
+	require.NoError(err)
+	assert.Zero(len(change))
+}
+
+func TestTransferOwnership(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(3, 1000)
+	owner, newOwner, other := senders[0], senders[1], senders[2]
+
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	deliverer.sender = newOwner
+	require.NoError(deliverer.delegate(newTxDelegate(5, pk1)))
+
+	// only the owner proposes, and only the proposed owner accepts
+	checker := check{store: store, sender: other}
+	assert.Error(checker.proposeOwner(TxProposeOwner{pk1, newOwner}))
+	checker.sender = owner
+	assert.Error(checker.proposeOwner(TxProposeOwner{pk1, owner}))
+	assert.NoError(checker.proposeOwner(TxProposeOwner{pk1, newOwner}))
+	checker.sender = newOwner
+	assert.Error(checker.acceptOwner(TxAcceptOwner{pk1}))
+
+	deliverer.sender = owner
+	require.NoError(deliverer.proposeOwner(TxProposeOwner{pk1, newOwner}))
+	assert.Equal(&newOwner, loadCandidate(store, pk1).PendingOwner)
+	checker.sender = other
+	assert.Error(checker.acceptOwner(TxAcceptOwner{pk1}))
+	checker.sender = newOwner
+	assert.NoError(checker.acceptOwner(TxAcceptOwner{pk1}))
+
+	// the self-bond joins the bond of the new owner
+	deliverer.sender = newOwner
+	deliverer.height = 7
+	require.NoError(deliverer.acceptOwner(TxAcceptOwner{pk1}))
+	candidate := loadCandidate(store, pk1)
+	assert.Equal(newOwner, candidate.Owner)
+	assert.Nil(candidate.PendingOwner)
+	assert.Equal([]OwnerChange{{7, owner, newOwner}}, candidate.OwnerChanges)
+	assert.Nil(loadDelegatorBond(store, owner, pk1))
+	assert.Zero(len(loadDelegatorCandidates(store, owner)))
+	assert.Equal(uint64(15), loadDelegatorBond(store, newOwner, pk1).Shares)
+	assert.Equal(uint64(15), candidate.Shares)
+
+	// the previous owner has no more rights
+	checker.sender = owner
+	assert.Error(checker.proposeOwner(TxProposeOwner{pk1, other}))
+	assert.Error(checker.acceptOwner(TxAcceptOwner{pk1}))
+}
+
+func TestCandidateOperator(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(3, 1000)
+	owner, operator, other := senders[0], senders[1], senders[2]
+
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	edit := TxEditCandidacy{pk1, Description{Moniker: "val"}}
+
+	// only the owner sets the operator
+	checker := check{store: store, sender: operator}
+	assert.Error(checker.editCandidacy(edit))
+	assert.Error(checker.setOperator(TxSetOperator{pk1, operator}))
+	checker.sender = owner
+	assert.NoError(checker.editCandidacy(edit))
+	assert.NoError(checker.setOperator(TxSetOperator{pk1, operator}))
+	require.NoError(deliverer.setOperator(TxSetOperator{pk1, operator}))
+	assert.Equal(&operator, loadCandidate(store, pk1).Operator)
+
+	// the operator manages the candidate
+	checker.sender = operator
+	assert.NoError(checker.editCandidacy(edit))
+	assert.NoError(checker.signalUpgrade(TxSignalUpgrade{pk1, "v1"}))
+	checker.sender = other
+	assert.Error(checker.editCandidacy(edit))
+	assert.Error(checker.signalUpgrade(TxSignalUpgrade{pk1, "v1"}))
+
+	// but can not touch the self-bond or the ownership
+	checker.sender = operator
+	assert.Error(checker.unbond(newTxUnbond(10, pk1)))
+	assert.Error(checker.proposeOwner(TxProposeOwner{pk1, operator}))
+	assert.Error(checker.setOperator(TxSetOperator{pk1, other}))
+	assert.Error(checker.rotateConsensusKey(TxRotateConsensusKey{pk1, pk2}))
+
+	// an empty operator removes it
+	require.NoError(deliverer.setOperator(TxSetOperator{pk1, sdk.Actor{}}))
+	assert.Nil(loadCandidate(store, pk1).Operator)
+	assert.Error(checker.editCandidacy(edit))
+}
+
+func TestRoleSender(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	sig1 := auth.SigPerm([]byte("signer1"))
+	sig2 := auth.SigPerm([]byte("signer2"))
+	role := roles.NewPerm([]byte("multisig"))
+
+	// a single signer is the sender
+	ctx := stack.MockContext("testChain", 1).WithPermissions(sig1)
+	sender, err := getTxSender(ctx)
+	require.NoError(err)
+	assert.Equal(sig1, sender)
+
+	// several signers need a role
+	ctx = stack.MockContext("testChain", 1).WithPermissions(sig1, sig2)
+	_, err = getTxSender(ctx)
+	assert.Error(err)
+	ctx = ctx.WithPermissions(role)
+	sender, err = getTxSender(ctx)
+	require.NoError(err)
+	assert.Equal(role, sender)
+	_, err = getTxSender(ctx.WithPermissions(roles.NewPerm([]byte("other"))))
+	assert.Error(err)
+
+	// the role owns the candidate and its self-bond
+	store := state.NewMemKVStore()
+	tx := NewTxDeclareCandidacy(coin.Coin{"fermion", 10}, pk1, Description{})
+	_, err = NewHandler().CheckTx(ctx, store, tx, nil)
+	require.NoError(err)
+
+	accStore := map[string]int64{string(role.Address): 1000}
+	deliverer := newDeliver(role, accStore)
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	assert.Equal(role, loadCandidate(deliverer.store, pk1).Owner)
+	assert.Equal(uint64(10), loadDelegatorBond(deliverer.store, role, pk1).Shares)
+
+	checker := check{store: deliverer.store, sender: role}
+	assert.NoError(checker.unbond(newTxUnbond(10, pk1)))
+	require.NoError(deliverer.unbond(newTxUnbond(10, pk1)))
+	assert.Equal(int64(1000), accStore[string(role.Address)])
+}
+
+func TestWithdrawAddress(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	delegator, custody := senders[0], senders[1]
+
+	deliverer := newDeliver(delegator, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(100, pk1)))
+	assert.Equal(delegator, loadWithdrawAddress(store, delegator))
+
+	// the unbonded coins are sent to the withdraw address
+	require.NoError(deliverer.setWithdrawAddress(TxSetWithdrawAddress{custody}))
+	assert.Equal(custody, loadWithdrawAddress(store, delegator))
+	require.NoError(deliverer.unbond(newTxUnbond(40, pk1)))
+	assert.Equal(int64(900), accStore[string(delegator.Address)])
+	assert.Equal(int64(1040), accStore[string(custody.Address)])
+
+	// the address is per delegator
+	assert.Equal(custody, loadWithdrawAddress(store, custody))
+
+	// an empty address sends them back to the delegator
+	require.NoError(deliverer.setWithdrawAddress(TxSetWithdrawAddress{}))
+	assert.Nil(store.Get(GetWithdrawAddressKey(delegator)))
+	require.NoError(deliverer.unbond(newTxUnbond(60, pk1)))
+	assert.Equal(int64(960), accStore[string(delegator.Address)])
+}
+
+func TestRevokeCandidacy(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(4, 1000)
+	owner, delegator1, delegator2, custody := senders[0], senders[1], senders[2], senders[3]
+
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	deliverer.sender = delegator1
+	require.NoError(deliverer.delegate(newTxDelegate(20, pk1)))
+	deliverer.sender = delegator2
+	require.NoError(deliverer.delegate(newTxDelegate(30, pk1)))
+	require.NoError(deliverer.setWithdrawAddress(TxSetWithdrawAddress{custody}))
+	_, err := UpdateValidatorSet(store)
+	require.NoError(err)
+
+	// only the owner revokes
+	checker := check{store: store, sender: delegator1}
+	assert.Error(checker.revokeCandidacy(TxRevokeCandidacy{pk1}))
+	checker.sender = owner
+	assert.NoError(checker.revokeCandidacy(TxRevokeCandidacy{pk1}))
+	assert.Error(checker.revokeCandidacy(TxRevokeCandidacy{pk2}))
+
+	// the delegators are tagged
+	ctx := stack.MockContext("testChain", 5)
+	tags := revokeTags(ctx, store, pk1)
+	require.Equal(4, len(tags))
+	delegators := map[string]bool{}
+	for _, tag := range tags[1:] {
+		assert.Equal("stake.delegator", tag.Key)
+		delegators[tag.ValueString] = true
+	}
+	for _, d := range []sdk.Actor{owner, delegator1, delegator2} {
+		assert.True(delegators[d.String()], "%v", d)
+	}
+
+	// all the bonds are returned
+	deliverer.sender = owner
+	require.NoError(deliverer.revokeCandidacy(TxRevokeCandidacy{pk1}))
+	assert.Nil(loadCandidate(store, pk1))
+	for _, d := range []sdk.Actor{owner, delegator1, delegator2} {
+		assert.Nil(loadDelegatorBond(store, d, pk1))
+		assert.Zero(len(loadDelegatorCandidates(store, d)))
+	}
+	assert.Empty(loadCandidateDelegators(store, pk1))
+	assert.Nil(store.Get(GetDelegatorCountKey(pk1)))
+	assert.Equal(int64(1000), accStore[string(owner.Address)])
+	assert.Equal(int64(1000), accStore[string(delegator1.Address)])
+	assert.Equal(int64(970), accStore[string(delegator2.Address)])
+	assert.Equal(int64(1030), accStore[string(custody.Address)])
+
+	// the validator is removed from the set
+	change, err := UpdateValidatorSet(store)
+	require.NoError(err)
+	require.Equal(1, len(change))
+	assert.Equal(pk1.Bytes(), change[0].PubKey)
+	assert.Equal(int64(0), change[0].Power)
+	change, err = UpdateValidatorSet(store)
+	require.NoError(err)
+	assert.Zero(len(change))
+
+	// so is a validator whose owner unbonds everything
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk2)))
+	_, err = UpdateValidatorSet(store)
+	require.NoError(err)
+	require.NoError(deliverer.unbond(newTxUnbond(10, pk2)))
+	change, err = UpdateValidatorSet(store)
+	require.NoError(err)
+	require.Equal(1, len(change))
+	assert.Equal(int64(0), change[0].Power)
+}
+
+func TestPauseCandidacy(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(3, 1000)
+
+	// two validator slots for three candidates
+	deliverer := newDeliver(senders[0], accStore)
+	store := deliverer.store
+	params := loadParams(store)
+	params.MaxVals = 2
+	saveParams(store, params)
+	deliverer.params = params
+	amounts := []int64{30, 20, 10}
+	for i, pk := range pks[:3] {
+		deliverer.sender = senders[i]
+		require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(amounts[i], pk)))
+	}
+	_, err := UpdateValidatorSet(store)
+	require.NoError(err)
+	assert.Equal(2, len(loadCandidates(store).Validators()))
+
+	// the operator pauses, only once
+	checker := check{store: store, sender: senders[1]}
+	assert.Error(checker.pauseCandidacy(TxPauseCandidacy{pks[0]}))
+	assert.Error(checker.resumeCandidacy(TxResumeCandidacy{pks[0]}))
+	checker.sender = senders[0]
+	assert.NoError(checker.pauseCandidacy(TxPauseCandidacy{pks[0]}))
+	deliverer.sender = senders[0]
+	deliverer.height = 10
+	require.NoError(deliverer.pauseCandidacy(TxPauseCandidacy{pks[0]}))
+	assert.Error(checker.pauseCandidacy(TxPauseCandidacy{pks[0]}))
+
+	// the next candidate takes the slot, the shares are kept
+	change, err := UpdateValidatorSet(store)
+	require.NoError(err)
+	require.Equal(2, len(change))
+	validators := loadCandidates(store).Validators()
+	require.Equal(2, len(validators))
+	for _, v := range validators {
+		assert.False(v.PubKey.Equals(pks[0]))
+	}
+	assert.Equal(uint64(30), loadCandidate(store, pks[0]).Shares)
+
+	// it resumes with a tx
+	assert.NoError(checker.resumeCandidacy(TxResumeCandidacy{pks[0]}))
+	require.NoError(deliverer.resumeCandidacy(TxResumeCandidacy{pks[0]}))
+	_, err = UpdateValidatorSet(store)
+	require.NoError(err)
+	assert.Equal(uint64(30), loadCandidate(store, pks[0]).VotingPower)
+
+	// or at the end of the maximum pause
+	require.NoError(deliverer.pauseCandidacy(TxPauseCandidacy{pks[0]}))
+	end := 10 + params.MaxPauseDuration
+	require.NoError(ProcessPauses(stack.MockContext("testChain", end-1), store))
+	assert.True(loadCandidate(store, pks[0]).paused())
+	require.NoError(ProcessPauses(stack.MockContext("testChain", end), store))
+	assert.False(loadCandidate(store, pks[0]).paused())
+}
+
+func TestMinBonds(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	owner, delegator := senders[0], senders[1]
+
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	params := loadParams(store)
+	params.MinSelfBond = 20
+	params.MinDelegation = 10
+	saveParams(store, params)
+	deliverer.params = params
+
+	// the candidate must bond the minimum self-bond
+	checker := check{store: store, sender: owner}
+	err := checker.declareCandidacy(newTxDeclareCandidacy(19, pk1))
+	assert.True(IsSelfBondTooLowErr(err), "%v", err)
+	assert.NoError(checker.declareCandidacy(newTxDeclareCandidacy(30, pk1)))
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(30, pk1)))
+
+	// the owner can top up by any amount
+	assert.NoError(checker.delegate(newTxDelegate(1, pk1)))
+
+	// a delegator must reach the minimum delegation, small top ups are fine
+	checker.sender = delegator
+	err = checker.delegate(newTxDelegate(9, pk1))
+	assert.True(IsDelegationTooLowErr(err), "%v", err)
+	assert.NoError(checker.delegate(newTxDelegate(10, pk1)))
+	deliverer.sender = delegator
+	require.NoError(deliverer.delegate(newTxDelegate(10, pk1)))
+	assert.NoError(checker.delegate(newTxDelegate(1, pk1)))
+
+	// partial unbonds must leave the minimum, full unbonds are always allowed
+	err = checker.unbond(newTxUnbond(1, pk1))
+	assert.True(IsDelegationTooLowErr(err), "%v", err)
+	assert.NoError(checker.unbond(newTxUnbond(10, pk1)))
+	checker.sender = owner
+	err = checker.unbond(newTxUnbond(11, pk1))
+	assert.True(IsSelfBondTooLowErr(err), "%v", err)
+	assert.NoError(checker.unbond(newTxUnbond(10, pk1)))
+	assert.NoError(checker.unbond(newTxUnbond(30, pk1)))
+
+	_, err = UpdateValidatorSet(store)
+	require.NoError(err)
+	assert.Equal(uint64(40), loadCandidate(store, pk1).VotingPower)
+
+	// a raised minimum drops the candidate out of the validator set
+	params.MinSelfBond = 31
+	saveParams(store, params)
+	change, err := UpdateValidatorSet(store)
+	require.NoError(err)
+	require.Equal(1, len(change))
+	assert.Zero(change[0].Power)
+	assert.Zero(len(loadCandidates(store).Validators()))
+	assert.Equal(uint64(40), loadCandidate(store, pk1).Shares)
+}
+
+func TestDelegationCaps(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(4, 1000)
+	owner1, owner2, delegator1, delegator2 := senders[0], senders[1], senders[2], senders[3]
+
+	deliverer := newDeliver(owner1, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(40, pk1)))
+	deliverer.sender = owner2
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(60, pk2)))
+
+	// a candidate can hold at most half of the bonded shares
+	params := loadParams(store)
+	params.MaxCandidatePowerFraction = 50
+	saveParams(store, params)
+	checker := check{store: store, sender: delegator1}
+	assert.NoError(checker.delegate(newTxDelegate(20, pk1)))
+	err := checker.delegate(newTxDelegate(21, pk1))
+	assert.True(IsCandidatePowerCapErr(err), "%v", err)
+	err = checker.delegate(newTxDelegate(1, pk2))
+	assert.True(IsCandidatePowerCapErr(err), "%v", err)
+
+	// the self-bond of a new candidate is capped the same way
+	checker.sender = delegator2
+	assert.NoError(checker.declareCandidacy(newTxDeclareCandidacy(100, pk3)))
+	err = checker.declareCandidacy(newTxDeclareCandidacy(101, pk3))
+	assert.True(IsCandidatePowerCapErr(err), "%v", err)
+	checker.sender = delegator1
+	params.MaxCandidatePowerFraction = 0
+	saveParams(store, params)
+
+	// the operator sets a ceiling on the shares of its candidate
+	assert.Error(checker.setDelegationCap(TxSetDelegationCap{pk1, 50}))
+	checker.sender = owner1
+	assert.NoError(checker.setDelegationCap(TxSetDelegationCap{pk1, 50}))
+	deliverer.sender = owner1
+	require.NoError(deliverer.setDelegationCap(TxSetDelegationCap{pk1, 50}))
+	checker.sender = delegator1
+	assert.NoError(checker.delegate(newTxDelegate(10, pk1)))
+	err = checker.delegate(newTxDelegate(11, pk1))
+	assert.True(IsCandidateMaxSharesErr(err), "%v", err)
+	require.NoError(deliverer.setDelegationCap(TxSetDelegationCap{pk1, 0}))
+	assert.NoError(checker.delegate(newTxDelegate(11, pk1)))
+
+	// a candidate can have at most two delegators, existing ones can top up
+	params.MaxDelegatorsPerCandidate = 2
+	saveParams(store, params)
+	deliverer.sender = delegator1
+	require.NoError(deliverer.delegate(newTxDelegate(10, pk1)))
+	checker.sender = delegator2
+	err = checker.delegate(newTxDelegate(10, pk1))
+	assert.True(IsTooManyDelegatorsErr(err), "%v", err)
+	assert.NoError(checker.delegate(newTxDelegate(10, pk2)))
+	checker.sender = delegator1
+	assert.NoError(checker.delegate(newTxDelegate(10, pk1)))
+}
+
+type recordCoinSender struct {
+	transfers *[]coin.Coins
+}
+
+func (c recordCoinSender) TransferFn(sender, receiver sdk.Actor, coins coin.Coins) error {
+	*c.transfers = append(*c.transfers, coins)
+	return nil
+}
+
+func TestHandlerOptions(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	var transfers []coin.Coins
+	h := NewHandler(
+		WithHoldAccount([]byte("vault")),
+		WithBondDenom("atom"),
+		WithCoinSend(func(ctx sdk.Context, store state.SimpleDB, dispatch sdk.Deliver) CoinSend {
+			return recordCoinSender{&transfers}
+		}),
+	)
+	store := state.NewMemKVStore()
+	ctx := stack.MockContext("testChain", 1).WithPermissions(auth.SigPerm([]byte("owner")))
+
+	// the txs do not save the params of the handler
+	tx := NewTxDeclareCandidacy(coin.Coin{"atom", 10}, pk1, Description{})
+	_, err := h.CheckTx(ctx, store, tx, nil)
+	assert.Error(err)
+	assert.False(store.Has(ParamKey))
+
+	// the params of the handler are saved by the genesis
+	require.NoError(h.initState(stakingModuleName, "max_vals", "50", store))
+	params := loadParams(store)
+	assert.Equal(sdk.NewActor(stakingModuleName, []byte("vault")), params.HoldAccount)
+	assert.Equal("atom", params.AllowedBondDenom)
+	assert.Equal(uint16(50), params.MaxVals)
+
+	// the coins are moved by the custom backend
+	_, err = h.CheckTx(ctx, store, tx, nil)
+	require.NoError(err)
+	_, err = h.DeliverTx(ctx, store, tx, nil)
+	require.NoError(err)
+	assert.Equal([]coin.Coins{{{"atom", 10}}}, transfers)
+
+	// the genesis overrides the params of the handler
+	require.NoError(h.initState(stakingModuleName, "allowed_bond_denom", "steak", store))
+	assert.Equal("steak", loadParams(store).AllowedBondDenom)
+}
@@ -0,0 +1,158 @@
+package stake
+
+import (
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// Hooks - called by the stake module on the staking events, so other
+// modules react to them. The store is the global store, the hooks reach the
+// store of a module with stack.PrefixedStore. The events of the txs are
+// queued in the stake store and the hooks are called on them at the end of
+// the block, by Handler.ProcessEpoch, the events of the tick are called
+// after the change is saved.
+type Hooks interface {
+	// CandidateCreated - a candidate was declared
+	CandidateCreated(ctx sdk.Context, store state.SimpleDB, candidate *Candidate)
+	// CandidateEdited - the description of a candidate was edited
+	CandidateEdited(ctx sdk.Context, store state.SimpleDB, candidate *Candidate)
+	// DelegationChanged - shares were delegated to a new or an existing
+	// bond, or moved between bonds by a transfer of ownership, a bond
+	// without shares left is removed from the store
+	DelegationChanged(ctx sdk.Context, store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond)
+	// Unbonded - shares were removed from a bond, which is removed from the
+	// store once it has no shares left
+	Unbonded(ctx sdk.Context, store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond, shares uint64)
+	// ValidatorAdded - a validator entered the validator set
+	ValidatorAdded(ctx sdk.Context, store state.SimpleDB, validator Validator)
+	// ValidatorRemoved - a validator left the validator set
+	ValidatorRemoved(ctx sdk.Context, store state.SimpleDB, pubKey crypto.PubKey)
+}
+
+// NoHooks - the Hooks doing nothing, embed it to implement only the hooks
+// of interest
+type NoHooks struct{}
+
+var _ Hooks = NoHooks{} // enforce interface at compile time
+
+// nolint
+func (NoHooks) CandidateCreated(sdk.Context, state.SimpleDB, *Candidate)                 {}
+func (NoHooks) CandidateEdited(sdk.Context, state.SimpleDB, *Candidate)                  {}
+func (NoHooks) DelegationChanged(sdk.Context, state.SimpleDB, sdk.Actor, *DelegatorBond) {}
+func (NoHooks) Unbonded(sdk.Context, state.SimpleDB, sdk.Actor, *DelegatorBond, uint64)  {}
+func (NoHooks) ValidatorAdded(sdk.Context, state.SimpleDB, Validator)                    {}
+func (NoHooks) ValidatorRemoved(sdk.Context, state.SimpleDB, crypto.PubKey)              {}
+
+// multiHooks - call all the hooks, in the order of their registration
+type multiHooks []Hooks
+
+var _ Hooks = multiHooks{} // enforce interface at compile time
+
+// nolint
+func (hs multiHooks) CandidateCreated(ctx sdk.Context, store state.SimpleDB, candidate *Candidate) {
+	for _, h := range hs {
+		h.CandidateCreated(ctx, store, candidate)
+	}
+}
+func (hs multiHooks) CandidateEdited(ctx sdk.Context, store state.SimpleDB, candidate *Candidate) {
+	for _, h := range hs {
+		h.CandidateEdited(ctx, store, candidate)
+	}
+}
+func (hs multiHooks) DelegationChanged(ctx sdk.Context, store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond) {
+	for _, h := range hs {
+		h.DelegationChanged(ctx, store, delegator, bond)
+	}
+}
+func (hs multiHooks) Unbonded(ctx sdk.Context, store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond, shares uint64) {
+	for _, h := range hs {
+		h.Unbonded(ctx, store, delegator, bond, shares)
+	}
+}
+func (hs multiHooks) ValidatorAdded(ctx sdk.Context, store state.SimpleDB, validator Validator) {
+	for _, h := range hs {
+		h.ValidatorAdded(ctx, store, validator)
+	}
+}
+func (hs multiHooks) ValidatorRemoved(ctx sdk.Context, store state.SimpleDB, pubKey crypto.PubKey) {
+	for _, h := range hs {
+		h.ValidatorRemoved(ctx, store, pubKey)
+	}
+}
+
+// kinds of the hook events
+const (
+	eventCandidateCreated byte = iota + 1
+	eventCandidateEdited
+	eventDelegationChanged
+	eventUnbonded
+)
+
+// hookEvent - a staking event of a tx, queued in the stake store until the
+// hooks are called at the end of the block
+type hookEvent struct {
+	Kind      byte
+	Candidate *Candidate
+	Delegator sdk.Actor
+	Bond      *DelegatorBond
+	Shares    uint64
+}
+
+// queueEvent - queue a staking event for the hooks, the events are only
+// queued if hooks are registered
+func (hs multiHooks) queueEvent(store state.SimpleDB, event hookEvent) {
+	if len(hs) == 0 {
+		return
+	}
+	queueHookEvent(store, event)
+}
+
+// processEvents - call the hooks on the staking events queued in the stake
+// store of the global store, in the order of the events
+func (hs multiHooks) processEvents(ctx sdk.Context, store state.SimpleDB) {
+	stakeStore := stack.PrefixedStore(stakingModuleName, store)
+	events := loadHookEvents(stakeStore)
+	if len(events) == 0 {
+		return
+	}
+	removeHookEvents(stakeStore)
+	for _, e := range events {
+		switch e.Kind {
+		case eventCandidateCreated:
+			hs.CandidateCreated(ctx, store, e.Candidate)
+		case eventCandidateEdited:
+			hs.CandidateEdited(ctx, store, e.Candidate)
+		case eventDelegationChanged:
+			hs.DelegationChanged(ctx, store, e.Delegator, e.Bond)
+		case eventUnbonded:
+			hs.Unbonded(ctx, store, e.Delegator, e.Bond, e.Shares)
+		}
+	}
+}
+
+// validatorSetChanged - call the hooks of the validators entering and
+// leaving the set, between the validator sets before and after an update
+func (hs multiHooks) validatorSetChanged(ctx sdk.Context, store state.SimpleDB, v1, v2 Validators) {
+	if len(hs) == 0 {
+		return
+	}
+	before := make(map[string]bool, len(v1))
+	for _, v := range v1 {
+		before[string(v.PubKey.Bytes())] = true
+	}
+	after := make(map[string]bool, len(v2))
+	for _, v := range v2 {
+		after[string(v.PubKey.Bytes())] = true
+		if !before[string(v.PubKey.Bytes())] {
+			hs.ValidatorAdded(ctx, store, v)
+		}
+	}
+	for _, v := range v1 {
+		if !after[string(v.PubKey.Bytes())] {
+			hs.ValidatorRemoved(ctx, store, v.PubKey)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package stake
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// recordHooks - record the events, implementing some of the hooks
+type recordHooks struct {
+	NoHooks
+	events *[]string
+}
+
+func (r recordHooks) CandidateCreated(ctx sdk.Context, store state.SimpleDB, candidate *Candidate) {
+	*r.events = append(*r.events, fmt.Sprintf("created %d", candidate.Shares))
+
+	// the hooks write to the store of their module
+	stack.PrefixedStore("hooks", store).Set([]byte("created"), candidate.PubKey.Bytes())
+}
+
+func (r recordHooks) CandidateEdited(ctx sdk.Context, store state.SimpleDB, candidate *Candidate) {
+	*r.events = append(*r.events, "edited "+candidate.Description.Moniker)
+}
+
+func (r recordHooks) DelegationChanged(ctx sdk.Context, store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond) {
+	*r.events = append(*r.events, fmt.Sprintf("delegation %d", bond.Shares))
+}
+
+func (r recordHooks) Unbonded(ctx sdk.Context, store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond, shares uint64) {
+	*r.events = append(*r.events, fmt.Sprintf("unbonded %d left %d", shares, bond.Shares))
+}
+
+func (r recordHooks) ValidatorAdded(ctx sdk.Context, store state.SimpleDB, validator Validator) {
+	*r.events = append(*r.events, fmt.Sprintf("added %d at %d", validator.VotingPower, ctx.BlockHeight()))
+}
+
+func (r recordHooks) ValidatorRemoved(ctx sdk.Context, store state.SimpleDB, pubKey crypto.PubKey) {
+	*r.events = append(*r.events, "removed")
+}
+
+func TestHooks(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	owner, delegator := senders[0], senders[1]
+
+	// the hooks of several options are all called
+	var events, others []string
+	h := NewHandler(
+		WithHooks(recordHooks{events: &events}),
+		WithHooks(recordHooks{events: &others}),
+	)
+	store := state.NewMemKVStore()
+	deliverer := newDeliver(owner, accStore)
+	deliverer.hooks = h.hooks
+	deliverer.store = stack.PrefixedStore(stakingModuleName, store)
+
+	// the events of the txs are called at the end of the block, with the
+	// global store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	require.NoError(deliverer.editCandidacy(TxEditCandidacy{pk1, Description{Moniker: "val"}}))
+	deliverer.sender = delegator
+	require.NoError(deliverer.delegate(newTxDelegate(5, pk1)))
+	require.NoError(deliverer.delegate(newTxDelegate(5, pk1)))
+	assert.Empty(events)
+	_, err := h.ProcessEpoch(stack.MockContext("testChain", 3), store, nil)
+	require.NoError(err)
+	assert.Equal([]string{"created 0", "delegation 10", "edited val",
+		"delegation 5", "delegation 10", "added 20 at 3"}, events)
+	assert.Equal(events, others)
+	assert.Equal(pk1.Bytes(), stack.PrefixedStore("hooks", store).Get([]byte("created")))
+	assert.Empty(loadHookEvents(deliverer.store))
+
+	events = nil
+	require.NoError(deliverer.unbond(newTxUnbond(4, pk1)))
+	_, err = h.ProcessEpoch(stack.MockContext("testChain", 4), store, nil)
+	require.NoError(err)
+	assert.Equal([]string{"unbonded 4 left 6"}, events)
+
+	events = nil
+	require.NoError(deliverer.unbond(newTxUnbond(6, pk1)))
+	deliverer.sender = owner
+	require.NoError(deliverer.unbond(newTxUnbond(10, pk1)))
+	_, err = h.ProcessEpoch(stack.MockContext("testChain", 5), store, nil)
+	require.NoError(err)
+	assert.Equal([]string{"unbonded 6 left 0", "unbonded 10 left 0", "removed"}, events)
+
+	// without hooks no event is queued
+	deliverer.hooks = nil
+	deliverer.sender = delegator
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk2)))
+	assert.Empty(loadHookEvents(deliverer.store))
+}
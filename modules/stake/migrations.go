@@ -0,0 +1,118 @@
+package stake
+
+import (
+	"bytes"
+
+	crypto "github.com/tendermint/go-crypto"
+	"github.com/tendermint/go-wire"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+func init() {
+	RegisterSchemaMigration(0, migrateSchemaV0)
+}
+
+// The layouts of the stored types in the stake store of the 0.5 release,
+// which did not record the version of its layout. They must not be changed.
+
+// candidateV0 - the layout of a Candidate in version 0
+type candidateV0 struct {
+	PubKey      crypto.PubKey
+	Owner       sdk.Actor
+	Shares      uint64
+	VotingPower uint64
+	Description Description
+}
+
+// paramsV0 - the layout of the Params in version 0
+type paramsV0 struct {
+	HoldAccount         sdk.Actor
+	MaxVals             uint16
+	AllowedBondDenom    string
+	GasDeclareCandidacy int64
+	GasEditCandidacy    int64
+	GasDelegate         int64
+	GasUnbond           int64
+}
+
+// migrateSchemaV0 - the params added since the release get their default
+// value, the candidates get their new fields, and their delegators are
+// indexed and counted
+func migrateSchemaV0(store state.SimpleDB) error {
+	if b := store.Get(ParamKey); b != nil {
+		var old paramsV0
+		err := wire.ReadBinaryBytes(b, &old)
+		if err != nil {
+			return err
+		}
+		params := defaultParams()
+		params.HoldAccount = old.HoldAccount
+		params.MaxVals = old.MaxVals
+		params.AllowedBondDenom = old.AllowedBondDenom
+		params.GasDeclareCandidacy = old.GasDeclareCandidacy
+		params.GasEditCandidacy = old.GasEditCandidacy
+		params.GasDelegate = old.GasDelegate
+		params.GasUnbond = old.GasUnbond
+		saveParams(store, params)
+	}
+
+	for _, pk := range loadCandidatesPubKeys(store) {
+		key := GetCandidateKey(pk)
+		b := store.Get(key)
+		if b == nil {
+			continue
+		}
+		var old candidateV0
+		err := wire.ReadBinaryBytes(b, &old)
+		if err != nil {
+			return err
+		}
+		candidate := &Candidate{
+			PubKey:      old.PubKey,
+			Owner:       old.Owner,
+			Shares:      old.Shares,
+			VotingPower: old.VotingPower,
+			Description: old.Description,
+			TargetPower: old.VotingPower,
+		}
+		saveCandidate(store, candidate)
+
+		// the bonds to the candidate are indexed and counted
+		delegators := scanCandidateDelegators(store, pk)
+		for _, delegator := range delegators {
+			store.Set(GetCandidateDelegatorKey(pk, delegator), wire.BinaryBytes(delegator))
+		}
+		saveDelegatorCount(store, pk, int64(len(delegators)))
+	}
+	return nil
+}
+
+// scanCandidateDelegators - the delegators of all the bonds to a candidate,
+// found by scanning all the bonds of the store
+func scanCandidateDelegators(store state.SimpleDB,
+	candidate crypto.PubKey) (delegators []sdk.Actor) {
+
+	pkBytes := candidate.Bytes()
+	prefixLen := len(DelegatorBondKeyPrefix)
+	for _, model := range store.List(DelegatorBondKeyPrefix, prefixEnd(DelegatorBondKeyPrefix), 0) {
+		key := model.Key
+		if len(key) <= prefixLen+len(pkBytes) ||
+			!bytes.Equal(key[len(key)-len(pkBytes):], pkBytes) {
+			continue
+		}
+
+		// the delegator is encoded as a pointer in the key
+		var delegator *sdk.Actor
+		err := wire.ReadBinaryBytes(key[prefixLen:len(key)-len(pkBytes)], &delegator)
+		if err != nil || delegator == nil {
+			continue // the pubkey bytes only matched part of the key
+		}
+		if !bytes.Equal(GetDelegatorBondKey(*delegator, candidate), key) {
+			continue
+		}
+		delegators = append(delegators, *delegator)
+	}
+	return
+}
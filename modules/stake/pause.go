@@ -0,0 +1,25 @@
+package stake
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// ProcessPauses - resume the candidates paused for maintenance for longer
+// than the MaxPauseDuration param, they are elected again by the validator
+// set update of the block
+func ProcessPauses(ctx sdk.Context, store state.SimpleDB) error {
+	height := ctx.BlockHeight()
+	params := loadParams(store)
+
+	for _, candidate := range loadCandidates(store) {
+		if !candidate.paused() || height-candidate.PausedHeight < params.MaxPauseDuration {
+			continue
+		}
+		candidate.PausedHeight = 0
+		saveCandidate(store, candidate)
+		ctx.Info("Candidate resumed at the end of its maximum pause",
+			"pubkey", candidate.PubKey, "height", height)
+	}
+	return nil
+}
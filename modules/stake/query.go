@@ -0,0 +1,295 @@
+package stake
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	wire "github.com/tendermint/go-wire"
+	cmn "github.com/tendermint/tmlibs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/auth"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// nolint - the custom ABCI query paths of the stake module, the
+// delegations path is followed by the address of the delegator
+const (
+	QueryPathPrefix  = "/stake/"
+	QueryValidators  = "/stake/validators"
+	QueryDelegations = "/stake/delegations/"
+	QueryPool        = "/stake/pool"
+	QueryCandidates  = "/stake/candidates"
+)
+
+// nolint - the statuses of a candidate
+const (
+	StatusValidator = "validator" // in the validator set
+	StatusCandidate = "candidate" // waiting for a slot in the validator set
+	StatusPaused    = "paused"    // paused for maintenance, it is not elected
+	StatusRevoked   = "revoked"   // revoked its candidacy
+)
+
+// nolint - the orders of a list of candidates
+const (
+	SortByPower   = "power"   // by rank
+	SortByMoniker = "moniker" // by moniker, then by rank
+)
+
+// RankedValidator - a validator with its rank by voting power, 1 for the
+// validator with the most voting power
+type RankedValidator struct {
+	Rank      int       `json:"rank"`
+	Validator Validator `json:"validator"`
+}
+
+// RankedCandidate - a candidate with its status and its rank by voting
+// power, as the validators are ranked. The share of the voting power of the
+// validators is a percent with four decimals.
+type RankedCandidate struct {
+	Rank       int        `json:"rank"`
+	PowerShare string     `json:"power_share"`
+	Status     string     `json:"status"`
+	Candidate  *Candidate `json:"candidate"`
+}
+
+// Delegation - a bond of a delegator with its candidate and the value of
+// its shares
+type Delegation struct {
+	Bond      DelegatorBond `json:"bond"`
+	Candidate *Candidate    `json:"candidate"`
+	Value     coin.Coin     `json:"value"`
+}
+
+// Pool - the tokens bonded on the chain
+type Pool struct {
+	HoldAccount      sdk.Actor `json:"hold_account"`
+	BondDenom        string    `json:"bond_denom"`
+	TotalBonded      uint64    `json:"total_bonded"`       // tokens bonded to all the candidates
+	TotalVotingPower uint64    `json:"total_voting_power"` // voting power of the validators
+	Candidates       int       `json:"candidates"`
+	Validators       int       `json:"validators"`
+}
+
+// RankedValidators - the validators, sorted by voting power
+func (v View) RankedValidators() (ranked []RankedValidator) {
+	candidates := loadCandidates(v.store)
+	candidates.Sort()
+	for _, validator := range candidates.Validators() {
+		ranked = append(ranked, RankedValidator{len(ranked) + 1, validator})
+	}
+	return
+}
+
+// Status - the status of the candidate
+func (c *Candidate) Status() string {
+	switch {
+	case c.Owner.Empty():
+		return StatusRevoked
+	case c.paused():
+		return StatusPaused
+	case c.VotingPower > 0:
+		return StatusValidator
+	}
+	return StatusCandidate
+}
+
+// RankCandidates - rank the candidates by voting power
+func RankCandidates(candidates Candidates) (ranked []RankedCandidate) {
+	sorted := make(Candidates, len(candidates))
+	copy(sorted, candidates)
+	sorted.Sort()
+
+	var total uint64
+	for _, candidate := range sorted {
+		total += candidate.VotingPower
+	}
+	for _, candidate := range sorted {
+		ranked = append(ranked, RankedCandidate{
+			Rank:       len(ranked) + 1,
+			PowerShare: powerShare(candidate.VotingPower, total),
+			Status:     candidate.Status(),
+			Candidate:  candidate,
+		})
+	}
+	return
+}
+
+// powerShare - the percent of the total power, with four decimals
+func powerShare(power, total uint64) string {
+	if total == 0 {
+		return "0.0000"
+	}
+	share := new(big.Int).SetUint64(power)
+	share.Mul(share, big.NewInt(1000000))
+	share.Quo(share, new(big.Int).SetUint64(total))
+	return fmt.Sprintf("%d.%04d", share.Int64()/10000, share.Int64()%10000)
+}
+
+// RankedCandidates - all the candidates, sorted by voting power
+func (v View) RankedCandidates() []RankedCandidate {
+	return RankCandidates(loadCandidates(v.store))
+}
+
+// CandidatesQuery - the selection of a page of ranked candidates
+type CandidatesQuery struct {
+	SortBy     string // SortByPower by default
+	Validators bool   // only the validators
+	Status     string // only the candidates of a status, all by default
+	Cursor     string // the next cursor of the previous page, none for the first page
+	Limit      int    // the maximum number of candidates of a page, 0 for all
+}
+
+// CandidatesPage - a page of ranked candidates
+type CandidatesPage struct {
+	Candidates []RankedCandidate `json:"candidates"`
+	Total      int               `json:"total"`          // candidates selected by the query
+	Next       string            `json:"next,omitempty"` // cursor of the next page, empty for the last page
+}
+
+// cursor - the position of a candidate in a page, the hex of its pubkey
+func (r RankedCandidate) cursor() string {
+	return hex.EncodeToString(r.Candidate.PubKey.Bytes())
+}
+
+// byMoniker - sort the ranked candidates by moniker
+type byMoniker []RankedCandidate
+
+func (b byMoniker) Len() int      { return len(b) }
+func (b byMoniker) Swap(i, j int) { b[i], b[j] = b[j], b[i] }
+func (b byMoniker) Less(i, j int) bool {
+	return b[i].Candidate.Description.Moniker < b[j].Candidate.Description.Moniker
+}
+
+// Page - the page of the ranked candidates selected by the query. The
+// cursor of a candidate which left the selection is rejected.
+func (q CandidatesQuery) Page(ranked []RankedCandidate) (page CandidatesPage, err error) {
+	switch q.SortBy {
+	case "", SortByPower, SortByMoniker:
+	default:
+		return page, ErrBadCandidatesQuery("unknown sort " + q.SortBy)
+	}
+	switch q.Status {
+	case "", StatusValidator, StatusCandidate, StatusPaused, StatusRevoked:
+	default:
+		return page, ErrBadCandidatesQuery("unknown status " + q.Status)
+	}
+	if q.Limit < 0 {
+		return page, ErrBadCandidatesQuery("negative limit")
+	}
+
+	var selected []RankedCandidate
+	for _, r := range ranked {
+		if q.Validators && r.Candidate.VotingPower == 0 {
+			continue
+		}
+		if q.Status != "" && r.Status != q.Status {
+			continue
+		}
+		selected = append(selected, r)
+	}
+	if q.SortBy == SortByMoniker {
+		sort.Stable(byMoniker(selected))
+	}
+	page.Total = len(selected)
+
+	start := 0
+	if q.Cursor != "" {
+		start = -1
+		for i, r := range selected {
+			if r.cursor() == q.Cursor {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return page, ErrBadCandidatesQuery("unknown cursor " + q.Cursor)
+		}
+	}
+	end := len(selected)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+		page.Next = selected[end-1].cursor()
+	}
+	page.Candidates = selected[start:end]
+	return page, nil
+}
+
+// Delegations - the bonds of a delegator, with their candidates
+func (v View) Delegations(delegator sdk.Actor) (delegations []Delegation) {
+	denom := loadParams(v.store).AllowedBondDenom
+	for _, bond := range v.DelegatorBonds(delegator) {
+		delegations = append(delegations, Delegation{
+			Bond:      bond,
+			Candidate: loadCandidate(v.store, bond.PubKey),
+			Value:     coin.Coin{denom, int64(bond.Shares)}, // currently each share is worth one coin
+		})
+	}
+	return
+}
+
+// Pool - the tokens bonded on the chain
+func (v View) Pool() (pool Pool) {
+	params := loadParams(v.store)
+	pool.HoldAccount = params.HoldAccount
+	pool.BondDenom = params.AllowedBondDenom
+	v.IterateCandidates(func(candidate *Candidate) bool {
+		pool.Candidates++
+		pool.TotalBonded += candidate.Shares
+		if candidate.VotingPower > 0 {
+			pool.Validators++
+			pool.TotalVotingPower += candidate.VotingPower
+		}
+		return false
+	})
+	return
+}
+
+// Query - serve a custom ABCI query path of the stake module from its
+// store, the result is go-wire encoded. The results are computed by the
+// node and have no proof, the keys they are computed from can be verified
+// with proofs at the same height.
+func Query(store state.SimpleDB, path string) ([]byte, error) {
+	view := NewView(store)
+	switch {
+	case path == QueryValidators:
+		return wire.BinaryBytes(view.RankedValidators()), nil
+	case path == QueryPool:
+		return wire.BinaryBytes(view.Pool()), nil
+	case path == QueryCandidates:
+		return wire.BinaryBytes(view.RankedCandidates()), nil
+	case strings.HasPrefix(path, QueryDelegations):
+		delegator, err := parseQueryActor(strings.TrimPrefix(path, QueryDelegations))
+		if err != nil {
+			return nil, err
+		}
+		return wire.BinaryBytes(view.Delegations(delegator)), nil
+	}
+	return nil, ErrUnknownQueryPath(path)
+}
+
+// parseQueryActor - parse an actor of a query path as the client commands
+// do: a hex address, with optional chain and app prefixes ("sigs" by
+// default) as in "chain:app:address"
+func parseQueryActor(input string) (actor sdk.Actor, err error) {
+	actor.App = auth.NameSigs
+	spl := strings.SplitN(input, ":", 3)
+	if len(spl) == 3 {
+		actor.ChainID, spl = spl[0], spl[1:]
+	}
+	if len(spl) == 2 {
+		if spl[0] != "" {
+			actor.App = spl[0]
+		}
+		spl = spl[1:]
+	}
+	actor.Address, err = hex.DecodeString(cmn.StripHex(spl[0]))
+	if err != nil || len(actor.Address) == 0 {
+		return actor, ErrBadQueryAddress(input)
+	}
+	return actor, nil
+}
@@ -0,0 +1,143 @@
+package stake
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	wire "github.com/tendermint/go-wire"
+
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+)
+
+func TestQuery(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	owner, delegator := senders[0], senders[1]
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(20, pk2)))
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(5, pk3)))
+	deliverer.sender = delegator
+	require.NoError(deliverer.delegate(newTxDelegate(15, pk1)))
+	params := loadParams(store)
+	params.MaxVals = 2
+	saveParams(store, params)
+	_, err := UpdateValidatorSet(store)
+	require.NoError(err)
+
+	// the validators are ranked by voting power
+	res, err := Query(store, QueryValidators)
+	require.NoError(err)
+	var validators []RankedValidator
+	require.NoError(wire.ReadBinaryBytes(res, &validators))
+	require.Equal(2, len(validators))
+	assert.Equal(1, validators[0].Rank)
+	assert.True(pk1.Equals(validators[0].Validator.PubKey))
+	assert.Equal(uint64(25), validators[0].Validator.VotingPower)
+	assert.Equal(2, validators[1].Rank)
+	assert.True(pk2.Equals(validators[1].Validator.PubKey))
+
+	// the delegations are joined with their candidates
+	res, err = Query(store, fmt.Sprintf("%s%s:%s:%X", QueryDelegations,
+		delegator.ChainID, delegator.App, []byte(delegator.Address)))
+	require.NoError(err)
+	var delegations []Delegation
+	require.NoError(wire.ReadBinaryBytes(res, &delegations))
+	require.Equal(1, len(delegations))
+	assert.Equal(uint64(15), delegations[0].Bond.Shares)
+	assert.Equal(uint64(25), delegations[0].Candidate.Shares)
+	assert.Equal(coin.Coin{"fermion", 15}, delegations[0].Value)
+
+	res, err = Query(store, QueryPool)
+	require.NoError(err)
+	var pool Pool
+	require.NoError(wire.ReadBinaryBytes(res, &pool))
+	assert.Equal(uint64(50), pool.TotalBonded)
+	assert.Equal(uint64(45), pool.TotalVotingPower)
+	assert.Equal(3, pool.Candidates)
+	assert.Equal(2, pool.Validators)
+	assert.Equal("fermion", pool.BondDenom)
+
+	res, err = Query(store, QueryCandidates)
+	require.NoError(err)
+	var candidates []RankedCandidate
+	require.NoError(wire.ReadBinaryBytes(res, &candidates))
+	require.Equal(3, len(candidates))
+	assert.Equal(StatusValidator, candidates[1].Status)
+	assert.Equal(StatusCandidate, candidates[2].Status)
+	assert.True(pk3.Equals(candidates[2].Candidate.PubKey))
+
+	// unknown paths and bad addresses are rejected
+	_, err = Query(store, "/stake/unknown")
+	assert.True(IsUnknownQueryPathErr(err), "%v", err)
+	_, err = Query(store, QueryDelegations+"sigs:xyz")
+	assert.Error(err)
+}
+
+func TestCandidatesPage(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	// validators of powers 40 and 10, a paused candidate and two candidates
+	// which are not elected
+	candidates := candidatesFromActors(newActors(5), []int{0, 40, 10, 0, 0})
+	for i, moniker := range []string{"e", "d", "c", "b", "a"} {
+		candidates[i].Description.Moniker = moniker
+	}
+	candidates[0].PausedHeight = 5
+
+	ranked := RankCandidates(candidates)
+	require.Equal(5, len(ranked))
+	assert.Equal(1, ranked[0].Rank)
+	assert.True(pks[1].Equals(ranked[0].Candidate.PubKey))
+	assert.Equal("80.0000", ranked[0].PowerShare)
+	assert.Equal(StatusValidator, ranked[0].Status)
+	assert.Equal("20.0000", ranked[1].PowerShare)
+	assert.Equal(StatusPaused, ranked[2].Status)
+	assert.Equal("0.0000", ranked[2].PowerShare)
+	assert.Equal(StatusCandidate, ranked[4].Status)
+
+	// the validators
+	page, err := CandidatesQuery{Validators: true}.Page(ranked)
+	require.NoError(err)
+	assert.Equal(2, page.Total)
+	assert.Equal("", page.Next)
+
+	// by moniker, two per page
+	q := CandidatesQuery{SortBy: SortByMoniker, Limit: 2}
+	var monikers []string
+	for {
+		page, err = q.Page(ranked)
+		require.NoError(err)
+		assert.Equal(5, page.Total)
+		for _, r := range page.Candidates {
+			monikers = append(monikers, r.Candidate.Description.Moniker)
+		}
+		if page.Next == "" {
+			break
+		}
+		q.Cursor = page.Next
+	}
+	assert.Equal([]string{"a", "b", "c", "d", "e"}, monikers)
+
+	// by status
+	page, err = CandidatesQuery{Status: StatusCandidate}.Page(ranked)
+	require.NoError(err)
+	require.Equal(2, page.Total)
+	assert.Equal(4, page.Candidates[0].Rank)
+
+	// bad queries are rejected
+	for _, q := range []CandidatesQuery{
+		{SortBy: "shares"},
+		{Status: "jailed"},
+		{Limit: -1},
+		{Cursor: "abcd"},
+	} {
+		_, err = q.Page(ranked)
+		assert.True(IsBadCandidatesQueryErr(err), "%+v: %v", q, err)
+	}
+}
@@ -3,20 +3,17 @@ package rest
 import (
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/gorilla/mux"
-	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
-	"github.com/cosmos/cosmos-sdk/client/commands"
 	"github.com/cosmos/cosmos-sdk/client/commands/query"
-	"github.com/cosmos/cosmos-sdk/modules/coin"
-	"github.com/cosmos/cosmos-sdk/stack"
 
 	"github.com/cosmos/gaia/modules/stake"
 	scmds "github.com/cosmos/gaia/modules/stake/commands"
 
-	crypto "github.com/tendermint/go-crypto"
 	"github.com/tendermint/tmlibs/common"
 )
 
@@ -28,7 +25,12 @@ func RegisterQueryCandidate(r *mux.Router) error {
 }
 
 // RegisterQueryCandidates is a mux.Router handler that exposes GET
-// method access on route /query/stake/candidate to query the group of all candidates
+// method access on route /query/stake/candidates to query a page of the
+// candidates ranked by voting power. The query string selects the page:
+// sort (power or moniker), validators (true for the validators only),
+// status, cursor (the next cursor of the previous page) and limit. With
+// prove=true the candidates are read with proofs instead of being ranked
+// by the node.
 func RegisterQueryCandidates(r *mux.Router) error {
 	r.HandleFunc("/query/stake/candidates", queryCandidates).Methods("GET")
 	return nil
@@ -48,6 +50,29 @@ func RegisterQueryDelegatorCandidates(r *mux.Router) error {
 	return nil
 }
 
+// RegisterQueryUpgradePlan is a mux.Router handler that exposes GET
+// method access on route /query/stake/upgrade_plan to query the scheduled upgrade
+func RegisterQueryUpgradePlan(r *mux.Router) error {
+	r.HandleFunc("/query/stake/upgrade_plan", queryUpgradePlan).Methods("GET")
+	return nil
+}
+
+// RegisterQueryEpoch is a mux.Router handler that exposes GET method access
+// on route /query/stake/epoch to query the current epoch and the height the
+// next one starts at
+func RegisterQueryEpoch(r *mux.Router) error {
+	r.HandleFunc("/query/stake/epoch", queryEpoch).Methods("GET")
+	return nil
+}
+
+// RegisterQueryWithdrawAddress is a mux.Router handler that exposes GET method
+// access on route /query/stake/withdraw_address/{address} to query the account
+// the unbonded coins of a delegator are returned to
+func RegisterQueryWithdrawAddress(r *mux.Router) error {
+	r.HandleFunc("/query/stake/withdraw_address/{address}", queryWithdrawAddress).Methods("GET")
+	return nil
+}
+
 //---------------------------------------------------------------------
 
 // queryCandidate is the HTTP handlerfunc to query a candidate
@@ -56,7 +81,6 @@ func queryCandidate(w http.ResponseWriter, r *http.Request) {
 
 	// get the arguments object
 	args := mux.Vars(r)
-	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
 
 	// get the pubkey
 	pkArg := args["pubkey"]
@@ -67,9 +91,12 @@ func queryCandidate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// get the candidate
-	var candidate stake.Candidate
-	key := stack.PrefixedKey(stake.Name(), stake.GetCandidateKey(pk))
-	height, err := query.GetParsed(key, &candidate, query.GetHeight(), prove)
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	candidate, height, err := c.Candidate(pk)
 	if client.IsNoDataErr(err) {
 		err := fmt.Errorf("candidate bytes are empty for pubkey: %q", pkArg)
 		common.WriteError(w, err)
@@ -86,32 +113,78 @@ func queryCandidate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// queryCandidates is the HTTP handlerfunc to query the group of all candidates
+// queryCandidates is the HTTP handlerfunc to query a page of the ranked
+// candidates
 func queryCandidates(w http.ResponseWriter, r *http.Request) {
 
-	var pks []crypto.PubKey
+	// get the selection of the page
+	q, prove, err := parseCandidatesQuery(r.URL.Query())
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	var ranked []stake.RankedCandidate
+	var height int64
+	if prove {
+		var candidates stake.Candidates
+		candidates, height, err = c.Candidates()
+		ranked = stake.RankCandidates(candidates)
+	} else {
+		ranked, height, err = c.RankedCandidates()
+	}
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
 
-	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
-	key := stack.PrefixedKey(stake.Name(), stake.CandidatesPubKeysKey)
-	height, err := query.GetParsed(key, &pks, query.GetHeight(), prove)
+	page, err := q.Page(ranked)
 	if err != nil {
 		common.WriteError(w, err)
 		return
 	}
 
-	err = query.FoutputProof(w, pks, height)
+	err = query.FoutputProof(w, page, height)
 	if err != nil {
 		common.WriteError(w, err)
 	}
 }
 
+// parseCandidatesQuery - the selection of a page of candidates in a query
+// string, and whether the candidates are read with proofs
+func parseCandidatesQuery(values url.Values) (q stake.CandidatesQuery, prove bool, err error) {
+	q.SortBy = values.Get("sort")
+	q.Status = values.Get("status")
+	q.Cursor = values.Get("cursor")
+	if v := values.Get("validators"); v != "" {
+		if q.Validators, err = strconv.ParseBool(v); err != nil {
+			return q, false, fmt.Errorf("validators must be a boolean: %q", v)
+		}
+	}
+	if v := values.Get("limit"); v != "" {
+		if q.Limit, err = strconv.Atoi(v); err != nil {
+			return q, false, fmt.Errorf("limit must be an integer: %q", v)
+		}
+	}
+	if v := values.Get("prove"); v != "" {
+		if prove, err = strconv.ParseBool(v); err != nil {
+			return q, false, fmt.Errorf("prove must be a boolean: %q", v)
+		}
+	}
+	return q, prove, nil
+}
+
 // queryDelegatorBond is the HTTP handlerfunc to query a delegator bond it
 // expects a query string
 func queryDelegatorBond(w http.ResponseWriter, r *http.Request) {
 
 	// get the arguments object
 	args := mux.Vars(r)
-	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
 
 	// get the pubkey
 	pkArg := args["pubkey"]
@@ -123,17 +196,19 @@ func queryDelegatorBond(w http.ResponseWriter, r *http.Request) {
 
 	// get the delegator actor
 	delegatorAddr := args["address"]
-	delegator, err := commands.ParseActor(delegatorAddr)
+	delegator, err := scmds.GetDelegator(delegatorAddr)
 	if err != nil {
 		common.WriteError(w, err)
 		return
 	}
-	delegator = coin.ChainAddr(delegator)
 
 	// get the bond
-	var bond stake.DelegatorBond
-	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondKey(delegator, pk))
-	height, err := query.GetParsed(key, &bond, query.GetHeight(), prove)
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	bond, height, err := c.Bond(delegator, pk)
 	if client.IsNoDataErr(err) {
 		err := fmt.Errorf("bond bytes are empty for pubkey: %q, address: %q", pkArg, delegatorAddr)
 		common.WriteError(w, err)
@@ -150,29 +225,51 @@ func queryDelegatorBond(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// queryDelegatorCandidates is the HTTP handlerfunc to query a delegator bond it
-// expects a query string
+// queryDelegatorCandidates is the HTTP handlerfunc to query the pubkeys of
+// the candidates a delegator is bonded to
 func queryDelegatorCandidates(w http.ResponseWriter, r *http.Request) {
 
 	// get the arguments object
 	args := mux.Vars(r)
-	prove := !viper.GetBool(commands.FlagTrustNode) // from viper because defined when starting server
 
 	// get the delegator actor
 	delegatorAddr := args["address"]
-	delegator, err := commands.ParseActor(delegatorAddr)
+	delegator, err := scmds.GetDelegator(delegatorAddr)
 	if err != nil {
 		common.WriteError(w, err)
 		return
 	}
-	delegator = coin.ChainAddr(delegator)
 
-	// get the bond
-	var bond stake.DelegatorBond
-	key := stack.PrefixedKey(stake.Name(), stake.GetDelegatorBondsKey(delegator))
-	height, err := query.GetParsed(key, &bond, query.GetHeight(), prove)
+	// get the candidates
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	candidates, height, err := c.DelegatorCandidates(delegator)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	// write the output
+	err = query.FoutputProof(w, candidates, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryUpgradePlan is the HTTP handlerfunc to query the scheduled upgrade
+func queryUpgradePlan(w http.ResponseWriter, r *http.Request) {
+
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	plan, height, err := c.UpgradePlan()
 	if client.IsNoDataErr(err) {
-		err := fmt.Errorf("bond bytes are empty for address: %q", delegatorAddr)
+		err := fmt.Errorf("no upgrade is scheduled")
 		common.WriteError(w, err)
 		return
 	} else if err != nil {
@@ -180,8 +277,60 @@ func queryDelegatorCandidates(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	err = query.FoutputProof(w, plan, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryEpoch is the HTTP handlerfunc to query the current epoch
+func queryEpoch(w http.ResponseWriter, r *http.Request) {
+
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	epoch, height, err := c.Epoch()
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	err = query.FoutputProof(w, epoch, height)
+	if err != nil {
+		common.WriteError(w, err)
+	}
+}
+
+// queryWithdrawAddress is the HTTP handlerfunc to query the withdraw address
+// of a delegator, the delegator itself if it did not set any
+func queryWithdrawAddress(w http.ResponseWriter, r *http.Request) {
+
+	// get the arguments object
+	args := mux.Vars(r)
+
+	// get the delegator actor
+	delegatorAddr := args["address"]
+	delegator, err := scmds.GetDelegator(delegatorAddr)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	c, err := scmds.GetClient() // from viper because defined when starting server
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	address, height, err := c.WithdrawAddress(delegator)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
 	// write the output
-	err = query.FoutputProof(w, bond, height)
+	err = query.FoutputProof(w, address, height)
 	if err != nil {
 		common.WriteError(w, err)
 	}
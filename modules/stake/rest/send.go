@@ -0,0 +1,197 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	crypto "github.com/tendermint/go-crypto"
+	keys "github.com/tendermint/go-crypto/keys"
+	"github.com/tendermint/go-wire/data"
+	"github.com/tendermint/tmlibs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/auth"
+
+	stakeclient "github.com/cosmos/gaia/modules/stake/client"
+	scmds "github.com/cosmos/gaia/modules/stake/commands"
+)
+
+// ServiceTxs exposes a REST API service building the stake txs, signing
+// them with the keys of the rest-server and broadcasting them in one call
+type ServiceTxs struct {
+	manager keys.Manager
+}
+
+// NewServiceTxs - a service signing the txs with the keys of manager
+func NewServiceTxs(manager keys.Manager) *ServiceTxs {
+	return &ServiceTxs{
+		manager: manager,
+	}
+}
+
+// keyInput - the key of the rest-server signing a tx
+type keyInput struct {
+	KeyName  string `json:"key_name" validate:"required,min=3,printascii"`
+	Password string `json:"password" validate:"required,min=10"`
+}
+
+type sendDeclareCandidacyInput struct {
+	keyInput
+	declareCandidacyInput
+}
+
+type sendEditCandidacyInput struct {
+	keyInput
+	editCandidacyInput
+}
+
+type sendDelegateInput struct {
+	keyInput
+	delegateInput
+}
+
+type sendUnbondInput struct {
+	keyInput
+	unbondInput
+}
+
+// SendResult - the outcome of a broadcast tx, a non-zero code for a tx
+// rejected by the node
+type SendResult struct {
+	Hash   data.Bytes `json:"hash"`
+	Height int64      `json:"height"`
+	Code   uint32     `json:"code"`
+	Log    string     `json:"log,omitempty"`
+}
+
+// RegisterSendTxs is a mux.Router handler that exposes POST method access
+// on the routes /tx/stake/declare-candidacy, /tx/stake/edit-candidacy,
+// /tx/stake/delegate and /tx/stake/unbond to build, sign and broadcast a
+// transaction, and wait for its commit
+func (s *ServiceTxs) RegisterSendTxs(r *mux.Router) error {
+	r.HandleFunc("/tx/stake/declare-candidacy", s.sendDeclareCandidacy).Methods("POST")
+	r.HandleFunc("/tx/stake/edit-candidacy", s.sendEditCandidacy).Methods("POST")
+	r.HandleFunc("/tx/stake/delegate", s.sendDelegate).Methods("POST")
+	r.HandleFunc("/tx/stake/unbond", s.sendUnbond).Methods("POST")
+	return nil
+}
+
+// builder - build a tx with its envelope
+type builder func(c stakeclient.Client, opts stakeclient.TxOptions) sdk.Tx
+
+// send - build a tx signed by a key, from the address of the key unless the
+// input has a sender, with the next nonce of the sender unless the input
+// has a sequence. Then broadcast it and write its result once committed.
+func (s *ServiceTxs) send(w http.ResponseWriter, key keyInput, in txInput,
+	pubKey crypto.PubKey, problems []string, build builder) {
+
+	info, err := s.manager.Get(key.KeyName)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	c, err := scmds.GetClient()
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	if in.From == nil {
+		signer := auth.SigPerm(info.Address)
+		in.From = &signer
+	}
+	if in.Sequence == 0 {
+		in.Sequence, _, err = c.NextSequence(*in.From)
+		if err != nil {
+			common.WriteError(w, err)
+			return
+		}
+	}
+
+	opts, inputProblems := in.options(pubKey)
+	problems = append(inputProblems, problems...)
+	if len(problems) > 0 {
+		writeProblems(w, problems)
+		return
+	}
+
+	tx := build(c, opts)
+	if sign, ok := tx.Unwrap().(keys.Signable); ok {
+		if err := s.manager.Sign(key.KeyName, key.Password, sign); err != nil {
+			common.WriteError(w, err)
+			return
+		}
+	}
+
+	res, err := c.Broadcast(tx)
+	if err != nil {
+		common.WriteError(w, err)
+		return
+	}
+	result := SendResult{
+		Hash:   res.Hash,
+		Height: res.Height,
+		Code:   res.CheckTx.Code,
+		Log:    res.CheckTx.Log,
+	}
+	if !res.CheckTx.IsErr() {
+		result.Code, result.Log = res.DeliverTx.Code, res.DeliverTx.Log
+	}
+	common.WriteSuccess(w, result)
+}
+
+func (s *ServiceTxs) sendDeclareCandidacy(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	si := new(sendDeclareCandidacyInput)
+	if err := common.ParseRequestAndValidateJSON(r, si); err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	s.send(w, si.keyInput, si.txInput, si.Pubkey, si.declareProblems(),
+		func(c stakeclient.Client, opts stakeclient.TxOptions) sdk.Tx {
+			return c.DeclareCandidacy(si.Amount, si.Pubkey, si.description(), opts)
+		})
+}
+
+func (s *ServiceTxs) sendEditCandidacy(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	si := new(sendEditCandidacyInput)
+	if err := common.ParseRequestAndValidateJSON(r, si); err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	s.send(w, si.keyInput, si.txInput, si.Pubkey, si.editProblems(),
+		func(c stakeclient.Client, opts stakeclient.TxOptions) sdk.Tx {
+			return c.EditCandidacy(si.Pubkey, si.description(), opts)
+		})
+}
+
+func (s *ServiceTxs) sendDelegate(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	si := new(sendDelegateInput)
+	if err := common.ParseRequestAndValidateJSON(r, si); err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	s.send(w, si.keyInput, si.txInput, si.Pubkey, nil,
+		func(c stakeclient.Client, opts stakeclient.TxOptions) sdk.Tx {
+			return c.Delegate(si.Amount, si.Pubkey, opts)
+		})
+}
+
+func (s *ServiceTxs) sendUnbond(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	si := new(sendUnbondInput)
+	if err := common.ParseRequestAndValidateJSON(r, si); err != nil {
+		common.WriteError(w, err)
+		return
+	}
+
+	s.send(w, si.keyInput, si.txInput, si.Pubkey, nil,
+		func(c stakeclient.Client, opts stakeclient.TxOptions) sdk.Tx {
+			return c.Unbond(si.Amount, si.Pubkey, opts)
+		})
+}
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"encoding/hex"
 	"net/http"
 	"strings"
 
@@ -9,13 +10,11 @@ import (
 	"github.com/tendermint/tmlibs/common"
 
 	sdk "github.com/cosmos/cosmos-sdk"
-	"github.com/cosmos/cosmos-sdk/client/commands"
-	"github.com/cosmos/cosmos-sdk/modules/auth"
-	"github.com/cosmos/cosmos-sdk/modules/base"
 	"github.com/cosmos/cosmos-sdk/modules/coin"
-	"github.com/cosmos/cosmos-sdk/modules/fee"
-	"github.com/cosmos/cosmos-sdk/modules/nonce"
+
 	"github.com/cosmos/gaia/modules/stake"
+	stakeclient "github.com/cosmos/gaia/modules/stake/client"
+	scmds "github.com/cosmos/gaia/modules/stake/commands"
 )
 
 const (
@@ -30,24 +29,65 @@ const (
 	paramDetails = "details"
 )
 
-type delegateInput struct {
+// txInput - the envelope of the txs built by the REST routes
+type txInput struct {
 	Fees     *coin.Coin `json:"fees"`
 	Sequence uint32     `json:"sequence"`
+	Role     string     `json:"role"`
+	Multi    bool       `json:"multi,omitempty"`
+	From     *sdk.Actor `json:"from"`
+}
+
+// descriptionInput - the description of a candidate, named as the url
+// params
+type descriptionInput struct {
+	Name    string `json:"name"`
+	Keybase string `json:"keybase"`
+	Website string `json:"website"`
+	Details string `json:"details"`
+}
 
+type declareCandidacyInput struct {
+	txInput
+	descriptionInput
 	Pubkey crypto.PubKey `json:"pub_key"`
-	From   *sdk.Actor    `json:"from"`
 	Amount coin.Coin     `json:"amount"`
 }
 
-type unbondInput struct {
-	Fees     *coin.Coin `json:"fees"`
-	Sequence uint32     `json:"sequence"`
+type editCandidacyInput struct {
+	txInput
+	descriptionInput
+	Pubkey crypto.PubKey `json:"pub_key"`
+}
+
+type delegateInput struct {
+	txInput
+	Pubkey crypto.PubKey `json:"pub_key"`
+	Amount coin.Coin     `json:"amount"`
+}
 
+type unbondInput struct {
+	txInput
 	Pubkey crypto.PubKey `json:"pub_key"`
-	From   *sdk.Actor    `json:"from"`
 	Amount uint64        `json:"amount"`
 }
 
+// RegisterDeclareCandidacy is a mux.Router handler that exposes
+// POST method access on route /build/stake/declare-candidacy to create a
+// transaction declaring a candidate with a self-bond
+func RegisterDeclareCandidacy(r *mux.Router) error {
+	r.HandleFunc("/build/stake/declare-candidacy", declareCandidacy).Methods("POST")
+	return nil
+}
+
+// RegisterEditCandidacy is a mux.Router handler that exposes
+// POST method access on route /build/stake/edit-candidacy to create a
+// transaction editing the description of a candidate
+func RegisterEditCandidacy(r *mux.Router) error {
+	r.HandleFunc("/build/stake/edit-candidacy", editCandidacy).Methods("POST")
+	return nil
+}
+
 // RegisterDelegate is a mux.Router handler that exposes
 // POST method access on route /tx/stake/delegate to create a
 // transaction for delegate to a candidaate/validator
@@ -64,66 +104,118 @@ func RegisterUnbond(r *mux.Router) error {
 	return nil
 }
 
-func prepareDelegateTx(di *delegateInput) sdk.Tx {
-	tx := stake.NewTxDelegate(di.Amount, di.Pubkey)
-	// fees are optional
-	if di.Fees != nil && !di.Fees.IsZero() {
-		tx = fee.NewFee(tx, *di.Fees, *di.From)
+// options - the envelope of a tx to the candidate of a pubkey, with the
+// problems of the input
+func (in txInput) options(pubKey crypto.PubKey) (opts stakeclient.TxOptions, problems []string) {
+	role, err := decodeRole(in.Role)
+	opts = stakeclient.TxOptions{
+		Fees:     in.Fees,
+		Sequence: in.Sequence,
+		Role:     role,
+		Multi:    in.Multi,
+	}
+	if in.From != nil {
+		opts.From = *in.From
+	}
+	problems = opts.Validate(pubKey)
+	if err != nil {
+		problems = append(problems, `"role" must be hex encoded`)
 	}
-	// only add the actual signer to the nonce
-	signers := []sdk.Actor{*di.From}
-	tx = nonce.NewTx(di.Sequence, signers, tx)
-	tx = base.NewChainTx(commands.GetChainID(), 0, tx)
+	return
+}
 
-	tx = auth.NewSig(tx).Wrap()
-	return tx
+// description - the description of the candidate
+func (in descriptionInput) description() stake.Description {
+	return stake.Description{
+		Moniker:  in.Name,
+		Identity: in.Keybase,
+		Website:  in.Website,
+		Details:  in.Details,
+	}
 }
 
-func delegate(w http.ResponseWriter, r *http.Request) {
+// declareProblems - the problems of the description of a new candidate
+func (in descriptionInput) declareProblems() []string {
+	return stakeclient.DeclareProblems(in.description())
+}
+
+// editProblems - the problems of the description of an edit
+func (in descriptionInput) editProblems() []string {
+	return stakeclient.EditProblems(in.description())
+}
+
+// writeProblems - reject an input with problems as a bad request
+func writeProblems(w http.ResponseWriter, problems []string) {
+	code := http.StatusBadRequest
+	err := &common.ErrorResponse{
+		Err:  strings.Join(problems, ", "),
+		Code: code,
+	}
+	common.WriteCode(w, err, code)
+}
+
+// decode the optional hex encoded role of a tx
+func decodeRole(roleInHex string) ([]byte, error) {
+	if roleInHex == "" {
+		return nil, nil
+	}
+	return hex.DecodeString(common.StripHex(roleInHex))
+}
+
+func declareCandidacy(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
-	di := new(delegateInput)
+	di := new(declareCandidacyInput)
 	if err := common.ParseRequestAndValidateJSON(r, di); err != nil {
 		common.WriteError(w, err)
 		return
 	}
 
-	var errsList []string
-	if di.From == nil {
-		errsList = append(errsList, `"from" cannot be nil`)
-	}
-	if di.Sequence <= 0 {
-		errsList = append(errsList, `"sequence" must be > 0`)
+	opts, problems := di.options(di.Pubkey)
+	problems = append(problems, di.declareProblems()...)
+	if len(problems) > 0 {
+		writeProblems(w, problems)
+		return
 	}
-	if di.Pubkey.Empty() {
-		errsList = append(errsList, `"pubkey" cannot be empty`)
+
+	tx := scmds.GetTxClient().DeclareCandidacy(di.Amount, di.Pubkey, di.description(), opts)
+	common.WriteSuccess(w, tx)
+}
+
+func editCandidacy(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	ei := new(editCandidacyInput)
+	if err := common.ParseRequestAndValidateJSON(r, ei); err != nil {
+		common.WriteError(w, err)
+		return
 	}
-	if len(errsList) > 0 {
-		code := http.StatusBadRequest
-		err := &common.ErrorResponse{
-			Err:  strings.Join(errsList, ", "),
-			Code: code,
-		}
-		common.WriteCode(w, err, code)
+
+	opts, problems := ei.options(ei.Pubkey)
+	problems = append(problems, ei.editProblems()...)
+	if len(problems) > 0 {
+		writeProblems(w, problems)
 		return
 	}
 
-	tx := prepareDelegateTx(di)
+	tx := scmds.GetTxClient().EditCandidacy(ei.Pubkey, ei.description(), opts)
 	common.WriteSuccess(w, tx)
 }
 
-func prepareUnbondTx(ui *unbondInput) sdk.Tx {
-	tx := stake.NewTxUnbond(ui.Amount, ui.Pubkey)
-	// fees are optional
-	if ui.Fees != nil && !ui.Fees.IsZero() {
-		tx = fee.NewFee(tx, *ui.Fees, *ui.From)
+func delegate(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	di := new(delegateInput)
+	if err := common.ParseRequestAndValidateJSON(r, di); err != nil {
+		common.WriteError(w, err)
+		return
 	}
-	// only add the actual signer to the nonce
-	signers := []sdk.Actor{*ui.From}
-	tx = nonce.NewTx(ui.Sequence, signers, tx)
-	tx = base.NewChainTx(commands.GetChainID(), 0, tx)
 
-	tx = auth.NewSig(tx).Wrap()
-	return tx
+	opts, problems := di.options(di.Pubkey)
+	if len(problems) > 0 {
+		writeProblems(w, problems)
+		return
+	}
+
+	tx := scmds.GetTxClient().Delegate(di.Amount, di.Pubkey, opts)
+	common.WriteSuccess(w, tx)
 }
 
 func unbond(w http.ResponseWriter, r *http.Request) {
@@ -134,26 +226,12 @@ func unbond(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var errsList []string
-	if ui.From == nil {
-		errsList = append(errsList, `"from" cannot be nil`)
-	}
-	if ui.Sequence <= 0 {
-		errsList = append(errsList, `"sequence" must be > 0`)
-	}
-	if ui.Pubkey.Empty() {
-		errsList = append(errsList, `"pubkey" cannot be empty`)
-	}
-	if len(errsList) > 0 {
-		code := http.StatusBadRequest
-		err := &common.ErrorResponse{
-			Err:  strings.Join(errsList, ", "),
-			Code: code,
-		}
-		common.WriteCode(w, err, code)
+	opts, problems := ui.options(ui.Pubkey)
+	if len(problems) > 0 {
+		writeProblems(w, problems)
 		return
 	}
 
-	tx := prepareUnbondTx(ui)
+	tx := scmds.GetTxClient().Unbond(ui.Amount, ui.Pubkey, opts)
 	common.WriteSuccess(w, tx)
 }
@@ -0,0 +1,129 @@
+package rpc
+
+import (
+	"fmt"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+
+	"github.com/cosmos/gaia/modules/stake"
+	stakeclient "github.com/cosmos/gaia/modules/stake/client"
+)
+
+// pubKeyFromBytes - the ed25519 pubkey of its 32 bytes, empty for no bytes
+func pubKeyFromBytes(bz []byte) (crypto.PubKey, error) {
+	if len(bz) == 0 {
+		return crypto.PubKey{}, nil
+	}
+	var pkEd crypto.PubKeyEd25519
+	if len(bz) != len(pkEd) {
+		return crypto.PubKey{}, fmt.Errorf("pubkey must be %d bytes long", len(pkEd))
+	}
+	copy(pkEd[:], bz)
+	return pkEd.Wrap(), nil
+}
+
+// pubKeyToBytes - the 32 bytes of an ed25519 pubkey, nil for the other keys
+func pubKeyToBytes(pk crypto.PubKey) []byte {
+	pkEd, ok := pk.Unwrap().(crypto.PubKeyEd25519)
+	if !ok {
+		return nil
+	}
+	return pkEd[:]
+}
+
+func pubKeysToBytes(pks []crypto.PubKey) (bzs [][]byte) {
+	for _, pk := range pks {
+		bzs = append(bzs, pubKeyToBytes(pk))
+	}
+	return
+}
+
+func actorFromPb(a *Actor) sdk.Actor {
+	if a == nil {
+		return sdk.Actor{}
+	}
+	return sdk.NewActor(a.App, a.Address).WithChain(a.ChainId)
+}
+
+func actorToPb(a sdk.Actor) *Actor {
+	return &Actor{
+		ChainId: a.ChainID,
+		App:     a.App,
+		Address: a.Address,
+	}
+}
+
+func optActorToPb(a *sdk.Actor) *Actor {
+	if a == nil {
+		return nil
+	}
+	return actorToPb(*a)
+}
+
+func coinFromPb(c *Coin) coin.Coin {
+	return coin.Coin{
+		Denom:  c.GetDenom(),
+		Amount: c.GetAmount(),
+	}
+}
+
+func descriptionFromPb(d *Description) stake.Description {
+	return stake.Description{
+		Moniker:  d.GetMoniker(),
+		Identity: d.GetIdentity(),
+		Website:  d.GetWebsite(),
+		Details:  d.GetDetails(),
+	}
+}
+
+func descriptionToPb(d stake.Description) *Description {
+	return &Description{
+		Moniker:  d.Moniker,
+		Identity: d.Identity,
+		Website:  d.Website,
+		Details:  d.Details,
+	}
+}
+
+func candidateToPb(c *stake.Candidate) *Candidate {
+	pb := &Candidate{
+		PubKey:       pubKeyToBytes(c.PubKey),
+		Owner:        actorToPb(c.Owner),
+		Shares:       c.Shares,
+		VotingPower:  c.VotingPower,
+		TargetPower:  c.TargetPower,
+		Description:  descriptionToPb(c.Description),
+		PendingOwner: optActorToPb(c.PendingOwner),
+		Operator:     optActorToPb(c.Operator),
+		PausedHeight: c.PausedHeight,
+		MaxShares:    c.MaxShares,
+	}
+	for _, change := range c.OwnerChanges {
+		pb.OwnerChanges = append(pb.OwnerChanges, &OwnerChange{
+			Height:   change.Height,
+			OldOwner: actorToPb(change.OldOwner),
+			NewOwner: actorToPb(change.NewOwner),
+		})
+	}
+	return pb
+}
+
+// txOptionsFromPb - the envelope of a tx, the missing fees are no fees
+func txOptionsFromPb(o *TxOptions) stakeclient.TxOptions {
+	opts := stakeclient.TxOptions{
+		Sequence: o.GetSequence(),
+		Role:     o.GetRole(),
+		Multi:    o.GetMulti(),
+	}
+	if o.GetFees() != nil {
+		fees := coinFromPb(o.GetFees())
+		opts.Fees = &fees
+	}
+	if o.GetFrom() != nil {
+		opts.From = actorFromPb(o.GetFrom())
+	}
+	return opts
+}
@@ -0,0 +1,265 @@
+package rpc
+
+import (
+	"encoding/json"
+	"strings"
+
+	context "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/modules/coin"
+
+	stakeclient "github.com/cosmos/gaia/modules/stake/client"
+)
+
+// Server - the gRPC service of the stake module, on the clients of the
+// REST routes: the queries read the node with a new client, the txs are
+// built by the tx client and checked as the REST builders check them.
+type Server struct {
+	newClient func() (stakeclient.Client, error)
+	txClient  stakeclient.Client
+}
+
+var _ StakeServer = Server{}
+
+// NewServer - a server querying the clients of newClient, and building the
+// txs with txClient
+func NewServer(newClient func() (stakeclient.Client, error), txClient stakeclient.Client) Server {
+	return Server{
+		newClient: newClient,
+		txClient:  txClient,
+	}
+}
+
+// client - the client reading the state at a height, 0 for the latest
+func (s Server) client(height int64) (stakeclient.Client, error) {
+	c, err := s.newClient()
+	if err != nil {
+		return c, status.Error(codes.Unavailable, err.Error())
+	}
+	return c.WithHeight(height), nil
+}
+
+// queryError - the status of a failed query, a missing object is not found
+func queryError(err error) error {
+	if client.IsNoDataErr(err) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Unknown, err.Error())
+}
+
+// invalidArgument - the status of a request with problems
+func invalidArgument(problems ...string) error {
+	return status.Error(codes.InvalidArgument, strings.Join(problems, ", "))
+}
+
+// delegator - the delegator of a request, an address of the chain of the
+// client unless it has a chain
+func (s Server) delegator(a *Actor) (sdk.Actor, error) {
+	if a == nil || len(a.Address) == 0 {
+		return sdk.Actor{}, invalidArgument(`"delegator" cannot be nil`)
+	}
+	return coin.ChainAddr(actorFromPb(a)), nil
+}
+
+// Candidate - the candidate of a pubkey
+func (s Server) Candidate(ctx context.Context, req *CandidateRequest) (*CandidateResponse, error) {
+	pk, err := pubKeyFromBytes(req.PubKey)
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	candidate, height, err := c.Candidate(pk)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &CandidateResponse{Candidate: candidateToPb(candidate), Height: height}, nil
+}
+
+// Candidates - the pubkeys of all the candidates
+func (s Server) Candidates(ctx context.Context, req *HeightRequest) (*PubKeysResponse, error) {
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	pks, height, err := c.CandidatesPubKeys()
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &PubKeysResponse{PubKeys: pubKeysToBytes(pks), Height: height}, nil
+}
+
+// DelegatorBond - the bond of a delegator to a candidate
+func (s Server) DelegatorBond(ctx context.Context, req *DelegatorBondRequest) (*DelegatorBondResponse, error) {
+	delegator, err := s.delegator(req.Delegator)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := pubKeyFromBytes(req.PubKey)
+	if err != nil {
+		return nil, invalidArgument(err.Error())
+	}
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	bond, height, err := c.Bond(delegator, pk)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &DelegatorBondResponse{
+		Bond:   &DelegatorBond{PubKey: pubKeyToBytes(bond.PubKey), Shares: bond.Shares},
+		Height: height,
+	}, nil
+}
+
+// DelegatorCandidates - the pubkeys of the candidates a delegator is bonded
+// to
+func (s Server) DelegatorCandidates(ctx context.Context, req *DelegatorRequest) (*PubKeysResponse, error) {
+	delegator, err := s.delegator(req.Delegator)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	pks, height, err := c.DelegatorCandidates(delegator)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &PubKeysResponse{PubKeys: pubKeysToBytes(pks), Height: height}, nil
+}
+
+// UpgradePlan - the scheduled software upgrade
+func (s Server) UpgradePlan(ctx context.Context, req *HeightRequest) (*UpgradePlanResponse, error) {
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	plan, height, err := c.UpgradePlan()
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &UpgradePlanResponse{
+		Plan:   &UpgradePlan{Name: plan.Name, Height: plan.Height},
+		Height: height,
+	}, nil
+}
+
+// Epoch - the current epoch
+func (s Server) Epoch(ctx context.Context, req *HeightRequest) (*EpochResponse, error) {
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	epoch, height, err := c.Epoch()
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &EpochResponse{
+		Epoch: &Epoch{
+			Number:      epoch.Number,
+			StartHeight: epoch.StartHeight,
+			NextHeight:  epoch.NextHeight,
+		},
+		Height: height,
+	}, nil
+}
+
+// WithdrawAddress - the account the unbonded coins of a delegator are
+// returned to
+func (s Server) WithdrawAddress(ctx context.Context, req *DelegatorRequest) (*ActorResponse, error) {
+	delegator, err := s.delegator(req.Delegator)
+	if err != nil {
+		return nil, err
+	}
+	c, err := s.client(req.Height)
+	if err != nil {
+		return nil, err
+	}
+	address, height, err := c.WithdrawAddress(delegator)
+	if err != nil {
+		return nil, queryError(err)
+	}
+	return &ActorResponse{Actor: actorToPb(address), Height: height}, nil
+}
+
+// txInput - the envelope and the candidate of a tx request, with the
+// problems the REST builders reject
+func txInput(o *TxOptions, pubKey []byte) (opts stakeclient.TxOptions, pk crypto.PubKey, err error) {
+	pk, err = pubKeyFromBytes(pubKey)
+	if err != nil {
+		return opts, pk, invalidArgument(err.Error())
+	}
+	opts = txOptionsFromPb(o)
+	if problems := opts.Validate(pk); len(problems) > 0 {
+		return opts, pk, invalidArgument(problems...)
+	}
+	return opts, pk, nil
+}
+
+// txResponse - the unsigned tx, encoded as the REST builders return it
+func txResponse(tx sdk.Tx) (*TxResponse, error) {
+	bz, err := json.Marshal(tx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &TxResponse{Tx: bz}, nil
+}
+
+// BuildDeclareCandidacy - build a tx declaring a candidate with a self-bond
+func (s Server) BuildDeclareCandidacy(ctx context.Context, req *DeclareCandidacyRequest) (*TxResponse, error) {
+	opts, pk, err := txInput(req.Options, req.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	description := descriptionFromPb(req.Description)
+	if problems := stakeclient.DeclareProblems(description); len(problems) > 0 {
+		return nil, invalidArgument(problems...)
+	}
+	tx := s.txClient.DeclareCandidacy(coinFromPb(req.Amount), pk, description, opts)
+	return txResponse(tx)
+}
+
+// BuildEditCandidacy - build a tx editing the description of a candidate
+func (s Server) BuildEditCandidacy(ctx context.Context, req *EditCandidacyRequest) (*TxResponse, error) {
+	opts, pk, err := txInput(req.Options, req.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	description := descriptionFromPb(req.Description)
+	if problems := stakeclient.EditProblems(description); len(problems) > 0 {
+		return nil, invalidArgument(problems...)
+	}
+	tx := s.txClient.EditCandidacy(pk, description, opts)
+	return txResponse(tx)
+}
+
+// BuildDelegate - build a tx delegating coins to a candidate
+func (s Server) BuildDelegate(ctx context.Context, req *DelegateRequest) (*TxResponse, error) {
+	opts, pk, err := txInput(req.Options, req.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	tx := s.txClient.Delegate(coinFromPb(req.Amount), pk, opts)
+	return txResponse(tx)
+}
+
+// BuildUnbond - build a tx unbonding shares from a candidate
+func (s Server) BuildUnbond(ctx context.Context, req *UnbondRequest) (*TxResponse, error) {
+	opts, pk, err := txInput(req.Options, req.PubKey)
+	if err != nil {
+		return nil, err
+	}
+	tx := s.txClient.Unbond(req.Shares, pk, opts)
+	return txResponse(tx)
+}
@@ -0,0 +1,1339 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: stake.proto
+
+/*
+Package rpc is a generated protocol buffer package.
+
+It is generated from these files:
+
+	stake.proto
+
+It has these top-level messages:
+
+	Actor
+	Coin
+	Description
+	OwnerChange
+	Candidate
+	DelegatorBond
+	UpgradePlan
+	Epoch
+	HeightRequest
+	CandidateRequest
+	DelegatorRequest
+	DelegatorBondRequest
+	CandidateResponse
+	PubKeysResponse
+	DelegatorBondResponse
+	UpgradePlanResponse
+	EpochResponse
+	ActorResponse
+	TxOptions
+	DeclareCandidacyRequest
+	EditCandidacyRequest
+	DelegateRequest
+	UnbondRequest
+	TxResponse
+*/
+package rpc
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
+
+type Actor struct {
+	ChainId string `protobuf:"bytes,1,opt,name=chain_id,json=chainId" json:"chain_id,omitempty"`
+	App     string `protobuf:"bytes,2,opt,name=app" json:"app,omitempty"`
+	Address []byte `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (m *Actor) Reset()                    { *m = Actor{} }
+func (m *Actor) String() string            { return proto.CompactTextString(m) }
+func (*Actor) ProtoMessage()               {}
+func (*Actor) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *Actor) GetChainId() string {
+	if m != nil {
+		return m.ChainId
+	}
+	return ""
+}
+
+func (m *Actor) GetApp() string {
+	if m != nil {
+		return m.App
+	}
+	return ""
+}
+
+func (m *Actor) GetAddress() []byte {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+type Coin struct {
+	Denom  string `protobuf:"bytes,1,opt,name=denom" json:"denom,omitempty"`
+	Amount int64  `protobuf:"varint,2,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *Coin) Reset()                    { *m = Coin{} }
+func (m *Coin) String() string            { return proto.CompactTextString(m) }
+func (*Coin) ProtoMessage()               {}
+func (*Coin) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *Coin) GetDenom() string {
+	if m != nil {
+		return m.Denom
+	}
+	return ""
+}
+
+func (m *Coin) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type Description struct {
+	Moniker  string `protobuf:"bytes,1,opt,name=moniker" json:"moniker,omitempty"`
+	Identity string `protobuf:"bytes,2,opt,name=identity" json:"identity,omitempty"`
+	Website  string `protobuf:"bytes,3,opt,name=website" json:"website,omitempty"`
+	Details  string `protobuf:"bytes,4,opt,name=details" json:"details,omitempty"`
+}
+
+func (m *Description) Reset()                    { *m = Description{} }
+func (m *Description) String() string            { return proto.CompactTextString(m) }
+func (*Description) ProtoMessage()               {}
+func (*Description) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *Description) GetMoniker() string {
+	if m != nil {
+		return m.Moniker
+	}
+	return ""
+}
+
+func (m *Description) GetIdentity() string {
+	if m != nil {
+		return m.Identity
+	}
+	return ""
+}
+
+func (m *Description) GetWebsite() string {
+	if m != nil {
+		return m.Website
+	}
+	return ""
+}
+
+func (m *Description) GetDetails() string {
+	if m != nil {
+		return m.Details
+	}
+	return ""
+}
+
+type OwnerChange struct {
+	Height   int64  `protobuf:"varint,1,opt,name=height" json:"height,omitempty"`
+	OldOwner *Actor `protobuf:"bytes,2,opt,name=old_owner,json=oldOwner" json:"old_owner,omitempty"`
+	NewOwner *Actor `protobuf:"bytes,3,opt,name=new_owner,json=newOwner" json:"new_owner,omitempty"`
+}
+
+func (m *OwnerChange) Reset()                    { *m = OwnerChange{} }
+func (m *OwnerChange) String() string            { return proto.CompactTextString(m) }
+func (*OwnerChange) ProtoMessage()               {}
+func (*OwnerChange) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *OwnerChange) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *OwnerChange) GetOldOwner() *Actor {
+	if m != nil {
+		return m.OldOwner
+	}
+	return nil
+}
+
+func (m *OwnerChange) GetNewOwner() *Actor {
+	if m != nil {
+		return m.NewOwner
+	}
+	return nil
+}
+
+type Candidate struct {
+	PubKey       []byte         `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Owner        *Actor         `protobuf:"bytes,2,opt,name=owner" json:"owner,omitempty"`
+	Shares       uint64         `protobuf:"varint,3,opt,name=shares" json:"shares,omitempty"`
+	VotingPower  uint64         `protobuf:"varint,4,opt,name=voting_power,json=votingPower" json:"voting_power,omitempty"`
+	TargetPower  uint64         `protobuf:"varint,5,opt,name=target_power,json=targetPower" json:"target_power,omitempty"`
+	Description  *Description   `protobuf:"bytes,6,opt,name=description" json:"description,omitempty"`
+	PendingOwner *Actor         `protobuf:"bytes,7,opt,name=pending_owner,json=pendingOwner" json:"pending_owner,omitempty"`
+	OwnerChanges []*OwnerChange `protobuf:"bytes,8,rep,name=owner_changes,json=ownerChanges" json:"owner_changes,omitempty"`
+	Operator     *Actor         `protobuf:"bytes,9,opt,name=operator" json:"operator,omitempty"`
+	PausedHeight int64          `protobuf:"varint,10,opt,name=paused_height,json=pausedHeight" json:"paused_height,omitempty"`
+	MaxShares    uint64         `protobuf:"varint,11,opt,name=max_shares,json=maxShares" json:"max_shares,omitempty"`
+}
+
+func (m *Candidate) Reset()                    { *m = Candidate{} }
+func (m *Candidate) String() string            { return proto.CompactTextString(m) }
+func (*Candidate) ProtoMessage()               {}
+func (*Candidate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Candidate) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *Candidate) GetOwner() *Actor {
+	if m != nil {
+		return m.Owner
+	}
+	return nil
+}
+
+func (m *Candidate) GetShares() uint64 {
+	if m != nil {
+		return m.Shares
+	}
+	return 0
+}
+
+func (m *Candidate) GetVotingPower() uint64 {
+	if m != nil {
+		return m.VotingPower
+	}
+	return 0
+}
+
+func (m *Candidate) GetTargetPower() uint64 {
+	if m != nil {
+		return m.TargetPower
+	}
+	return 0
+}
+
+func (m *Candidate) GetDescription() *Description {
+	if m != nil {
+		return m.Description
+	}
+	return nil
+}
+
+func (m *Candidate) GetPendingOwner() *Actor {
+	if m != nil {
+		return m.PendingOwner
+	}
+	return nil
+}
+
+func (m *Candidate) GetOwnerChanges() []*OwnerChange {
+	if m != nil {
+		return m.OwnerChanges
+	}
+	return nil
+}
+
+func (m *Candidate) GetOperator() *Actor {
+	if m != nil {
+		return m.Operator
+	}
+	return nil
+}
+
+func (m *Candidate) GetPausedHeight() int64 {
+	if m != nil {
+		return m.PausedHeight
+	}
+	return 0
+}
+
+func (m *Candidate) GetMaxShares() uint64 {
+	if m != nil {
+		return m.MaxShares
+	}
+	return 0
+}
+
+type DelegatorBond struct {
+	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Shares uint64 `protobuf:"varint,2,opt,name=shares" json:"shares,omitempty"`
+}
+
+func (m *DelegatorBond) Reset()                    { *m = DelegatorBond{} }
+func (m *DelegatorBond) String() string            { return proto.CompactTextString(m) }
+func (*DelegatorBond) ProtoMessage()               {}
+func (*DelegatorBond) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *DelegatorBond) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DelegatorBond) GetShares() uint64 {
+	if m != nil {
+		return m.Shares
+	}
+	return 0
+}
+
+type UpgradePlan struct {
+	Name   string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *UpgradePlan) Reset()                    { *m = UpgradePlan{} }
+func (m *UpgradePlan) String() string            { return proto.CompactTextString(m) }
+func (*UpgradePlan) ProtoMessage()               {}
+func (*UpgradePlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *UpgradePlan) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *UpgradePlan) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type Epoch struct {
+	Number      int64 `protobuf:"varint,1,opt,name=number" json:"number,omitempty"`
+	StartHeight int64 `protobuf:"varint,2,opt,name=start_height,json=startHeight" json:"start_height,omitempty"`
+	NextHeight  int64 `protobuf:"varint,3,opt,name=next_height,json=nextHeight" json:"next_height,omitempty"`
+}
+
+func (m *Epoch) Reset()                    { *m = Epoch{} }
+func (m *Epoch) String() string            { return proto.CompactTextString(m) }
+func (*Epoch) ProtoMessage()               {}
+func (*Epoch) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *Epoch) GetNumber() int64 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Epoch) GetStartHeight() int64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *Epoch) GetNextHeight() int64 {
+	if m != nil {
+		return m.NextHeight
+	}
+	return 0
+}
+
+type HeightRequest struct {
+	Height int64 `protobuf:"varint,1,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *HeightRequest) Reset()                    { *m = HeightRequest{} }
+func (m *HeightRequest) String() string            { return proto.CompactTextString(m) }
+func (*HeightRequest) ProtoMessage()               {}
+func (*HeightRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+
+func (m *HeightRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type CandidateRequest struct {
+	PubKey []byte `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *CandidateRequest) Reset()                    { *m = CandidateRequest{} }
+func (m *CandidateRequest) String() string            { return proto.CompactTextString(m) }
+func (*CandidateRequest) ProtoMessage()               {}
+func (*CandidateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *CandidateRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *CandidateRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type DelegatorRequest struct {
+	Delegator *Actor `protobuf:"bytes,1,opt,name=delegator" json:"delegator,omitempty"`
+	Height    int64  `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *DelegatorRequest) Reset()                    { *m = DelegatorRequest{} }
+func (m *DelegatorRequest) String() string            { return proto.CompactTextString(m) }
+func (*DelegatorRequest) ProtoMessage()               {}
+func (*DelegatorRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *DelegatorRequest) GetDelegator() *Actor {
+	if m != nil {
+		return m.Delegator
+	}
+	return nil
+}
+
+func (m *DelegatorRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type DelegatorBondRequest struct {
+	Delegator *Actor `protobuf:"bytes,1,opt,name=delegator" json:"delegator,omitempty"`
+	PubKey    []byte `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Height    int64  `protobuf:"varint,3,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *DelegatorBondRequest) Reset()                    { *m = DelegatorBondRequest{} }
+func (m *DelegatorBondRequest) String() string            { return proto.CompactTextString(m) }
+func (*DelegatorBondRequest) ProtoMessage()               {}
+func (*DelegatorBondRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *DelegatorBondRequest) GetDelegator() *Actor {
+	if m != nil {
+		return m.Delegator
+	}
+	return nil
+}
+
+func (m *DelegatorBondRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DelegatorBondRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type CandidateResponse struct {
+	Candidate *Candidate `protobuf:"bytes,1,opt,name=candidate" json:"candidate,omitempty"`
+	Height    int64      `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *CandidateResponse) Reset()                    { *m = CandidateResponse{} }
+func (m *CandidateResponse) String() string            { return proto.CompactTextString(m) }
+func (*CandidateResponse) ProtoMessage()               {}
+func (*CandidateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *CandidateResponse) GetCandidate() *Candidate {
+	if m != nil {
+		return m.Candidate
+	}
+	return nil
+}
+
+func (m *CandidateResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type PubKeysResponse struct {
+	PubKeys [][]byte `protobuf:"bytes,1,rep,name=pub_keys,json=pubKeys,proto3" json:"pub_keys,omitempty"`
+	Height  int64    `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *PubKeysResponse) Reset()                    { *m = PubKeysResponse{} }
+func (m *PubKeysResponse) String() string            { return proto.CompactTextString(m) }
+func (*PubKeysResponse) ProtoMessage()               {}
+func (*PubKeysResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *PubKeysResponse) GetPubKeys() [][]byte {
+	if m != nil {
+		return m.PubKeys
+	}
+	return nil
+}
+
+func (m *PubKeysResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type DelegatorBondResponse struct {
+	Bond   *DelegatorBond `protobuf:"bytes,1,opt,name=bond" json:"bond,omitempty"`
+	Height int64          `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *DelegatorBondResponse) Reset()                    { *m = DelegatorBondResponse{} }
+func (m *DelegatorBondResponse) String() string            { return proto.CompactTextString(m) }
+func (*DelegatorBondResponse) ProtoMessage()               {}
+func (*DelegatorBondResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *DelegatorBondResponse) GetBond() *DelegatorBond {
+	if m != nil {
+		return m.Bond
+	}
+	return nil
+}
+
+func (m *DelegatorBondResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type UpgradePlanResponse struct {
+	Plan   *UpgradePlan `protobuf:"bytes,1,opt,name=plan" json:"plan,omitempty"`
+	Height int64        `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *UpgradePlanResponse) Reset()                    { *m = UpgradePlanResponse{} }
+func (m *UpgradePlanResponse) String() string            { return proto.CompactTextString(m) }
+func (*UpgradePlanResponse) ProtoMessage()               {}
+func (*UpgradePlanResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+
+func (m *UpgradePlanResponse) GetPlan() *UpgradePlan {
+	if m != nil {
+		return m.Plan
+	}
+	return nil
+}
+
+func (m *UpgradePlanResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type EpochResponse struct {
+	Epoch  *Epoch `protobuf:"bytes,1,opt,name=epoch" json:"epoch,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *EpochResponse) Reset()                    { *m = EpochResponse{} }
+func (m *EpochResponse) String() string            { return proto.CompactTextString(m) }
+func (*EpochResponse) ProtoMessage()               {}
+func (*EpochResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+
+func (m *EpochResponse) GetEpoch() *Epoch {
+	if m != nil {
+		return m.Epoch
+	}
+	return nil
+}
+
+func (m *EpochResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type ActorResponse struct {
+	Actor  *Actor `protobuf:"bytes,1,opt,name=actor" json:"actor,omitempty"`
+	Height int64  `protobuf:"varint,2,opt,name=height" json:"height,omitempty"`
+}
+
+func (m *ActorResponse) Reset()                    { *m = ActorResponse{} }
+func (m *ActorResponse) String() string            { return proto.CompactTextString(m) }
+func (*ActorResponse) ProtoMessage()               {}
+func (*ActorResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *ActorResponse) GetActor() *Actor {
+	if m != nil {
+		return m.Actor
+	}
+	return nil
+}
+
+func (m *ActorResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+// TxOptions - the envelope of a tx: the optional fees and role, the signer
+// and its nonce. A tx which assumes a role is sent by the role, and it must
+// be signed by enough of its signers, collected with multi.
+type TxOptions struct {
+	Fees     *Coin  `protobuf:"bytes,1,opt,name=fees" json:"fees,omitempty"`
+	From     *Actor `protobuf:"bytes,2,opt,name=from" json:"from,omitempty"`
+	Sequence uint32 `protobuf:"varint,3,opt,name=sequence" json:"sequence,omitempty"`
+	Role     []byte `protobuf:"bytes,4,opt,name=role,proto3" json:"role,omitempty"`
+	Multi    bool   `protobuf:"varint,5,opt,name=multi" json:"multi,omitempty"`
+}
+
+func (m *TxOptions) Reset()                    { *m = TxOptions{} }
+func (m *TxOptions) String() string            { return proto.CompactTextString(m) }
+func (*TxOptions) ProtoMessage()               {}
+func (*TxOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *TxOptions) GetFees() *Coin {
+	if m != nil {
+		return m.Fees
+	}
+	return nil
+}
+
+func (m *TxOptions) GetFrom() *Actor {
+	if m != nil {
+		return m.From
+	}
+	return nil
+}
+
+func (m *TxOptions) GetSequence() uint32 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *TxOptions) GetRole() []byte {
+	if m != nil {
+		return m.Role
+	}
+	return nil
+}
+
+func (m *TxOptions) GetMulti() bool {
+	if m != nil {
+		return m.Multi
+	}
+	return false
+}
+
+type DeclareCandidacyRequest struct {
+	Options     *TxOptions   `protobuf:"bytes,1,opt,name=options" json:"options,omitempty"`
+	PubKey      []byte       `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Amount      *Coin        `protobuf:"bytes,3,opt,name=amount" json:"amount,omitempty"`
+	Description *Description `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *DeclareCandidacyRequest) Reset()                    { *m = DeclareCandidacyRequest{} }
+func (m *DeclareCandidacyRequest) String() string            { return proto.CompactTextString(m) }
+func (*DeclareCandidacyRequest) ProtoMessage()               {}
+func (*DeclareCandidacyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *DeclareCandidacyRequest) GetOptions() *TxOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *DeclareCandidacyRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DeclareCandidacyRequest) GetAmount() *Coin {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+func (m *DeclareCandidacyRequest) GetDescription() *Description {
+	if m != nil {
+		return m.Description
+	}
+	return nil
+}
+
+type EditCandidacyRequest struct {
+	Options     *TxOptions   `protobuf:"bytes,1,opt,name=options" json:"options,omitempty"`
+	PubKey      []byte       `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Description *Description `protobuf:"bytes,3,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *EditCandidacyRequest) Reset()                    { *m = EditCandidacyRequest{} }
+func (m *EditCandidacyRequest) String() string            { return proto.CompactTextString(m) }
+func (*EditCandidacyRequest) ProtoMessage()               {}
+func (*EditCandidacyRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+func (m *EditCandidacyRequest) GetOptions() *TxOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *EditCandidacyRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *EditCandidacyRequest) GetDescription() *Description {
+	if m != nil {
+		return m.Description
+	}
+	return nil
+}
+
+type DelegateRequest struct {
+	Options *TxOptions `protobuf:"bytes,1,opt,name=options" json:"options,omitempty"`
+	PubKey  []byte     `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Amount  *Coin      `protobuf:"bytes,3,opt,name=amount" json:"amount,omitempty"`
+}
+
+func (m *DelegateRequest) Reset()                    { *m = DelegateRequest{} }
+func (m *DelegateRequest) String() string            { return proto.CompactTextString(m) }
+func (*DelegateRequest) ProtoMessage()               {}
+func (*DelegateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *DelegateRequest) GetOptions() *TxOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *DelegateRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *DelegateRequest) GetAmount() *Coin {
+	if m != nil {
+		return m.Amount
+	}
+	return nil
+}
+
+type UnbondRequest struct {
+	Options *TxOptions `protobuf:"bytes,1,opt,name=options" json:"options,omitempty"`
+	PubKey  []byte     `protobuf:"bytes,2,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Shares  uint64     `protobuf:"varint,3,opt,name=shares" json:"shares,omitempty"`
+}
+
+func (m *UnbondRequest) Reset()                    { *m = UnbondRequest{} }
+func (m *UnbondRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnbondRequest) ProtoMessage()               {}
+func (*UnbondRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *UnbondRequest) GetOptions() *TxOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
+func (m *UnbondRequest) GetPubKey() []byte {
+	if m != nil {
+		return m.PubKey
+	}
+	return nil
+}
+
+func (m *UnbondRequest) GetShares() uint64 {
+	if m != nil {
+		return m.Shares
+	}
+	return 0
+}
+
+// TxResponse - the go-wire JSON of the unsigned tx, as returned by the REST
+// builders, to be signed by the keys service
+type TxResponse struct {
+	Tx []byte `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (m *TxResponse) Reset()                    { *m = TxResponse{} }
+func (m *TxResponse) String() string            { return proto.CompactTextString(m) }
+func (*TxResponse) ProtoMessage()               {}
+func (*TxResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *TxResponse) GetTx() []byte {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Actor)(nil), "rpc.Actor")
+	proto.RegisterType((*Coin)(nil), "rpc.Coin")
+	proto.RegisterType((*Description)(nil), "rpc.Description")
+	proto.RegisterType((*OwnerChange)(nil), "rpc.OwnerChange")
+	proto.RegisterType((*Candidate)(nil), "rpc.Candidate")
+	proto.RegisterType((*DelegatorBond)(nil), "rpc.DelegatorBond")
+	proto.RegisterType((*UpgradePlan)(nil), "rpc.UpgradePlan")
+	proto.RegisterType((*Epoch)(nil), "rpc.Epoch")
+	proto.RegisterType((*HeightRequest)(nil), "rpc.HeightRequest")
+	proto.RegisterType((*CandidateRequest)(nil), "rpc.CandidateRequest")
+	proto.RegisterType((*DelegatorRequest)(nil), "rpc.DelegatorRequest")
+	proto.RegisterType((*DelegatorBondRequest)(nil), "rpc.DelegatorBondRequest")
+	proto.RegisterType((*CandidateResponse)(nil), "rpc.CandidateResponse")
+	proto.RegisterType((*PubKeysResponse)(nil), "rpc.PubKeysResponse")
+	proto.RegisterType((*DelegatorBondResponse)(nil), "rpc.DelegatorBondResponse")
+	proto.RegisterType((*UpgradePlanResponse)(nil), "rpc.UpgradePlanResponse")
+	proto.RegisterType((*EpochResponse)(nil), "rpc.EpochResponse")
+	proto.RegisterType((*ActorResponse)(nil), "rpc.ActorResponse")
+	proto.RegisterType((*TxOptions)(nil), "rpc.TxOptions")
+	proto.RegisterType((*DeclareCandidacyRequest)(nil), "rpc.DeclareCandidacyRequest")
+	proto.RegisterType((*EditCandidacyRequest)(nil), "rpc.EditCandidacyRequest")
+	proto.RegisterType((*DelegateRequest)(nil), "rpc.DelegateRequest")
+	proto.RegisterType((*UnbondRequest)(nil), "rpc.UnbondRequest")
+	proto.RegisterType((*TxResponse)(nil), "rpc.TxResponse")
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Stake service
+
+type StakeClient interface {
+	// Candidate - the candidate of a pubkey
+	Candidate(ctx context.Context, in *CandidateRequest, opts ...grpc.CallOption) (*CandidateResponse, error)
+	// Candidates - the pubkeys of all the candidates
+	Candidates(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*PubKeysResponse, error)
+	// DelegatorBond - the bond of a delegator to a candidate
+	DelegatorBond(ctx context.Context, in *DelegatorBondRequest, opts ...grpc.CallOption) (*DelegatorBondResponse, error)
+	// DelegatorCandidates - the pubkeys of the candidates a delegator is
+	// bonded to
+	DelegatorCandidates(ctx context.Context, in *DelegatorRequest, opts ...grpc.CallOption) (*PubKeysResponse, error)
+	// UpgradePlan - the scheduled software upgrade
+	UpgradePlan(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*UpgradePlanResponse, error)
+	// Epoch - the current epoch
+	Epoch(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*EpochResponse, error)
+	// WithdrawAddress - the account the unbonded coins of a delegator are
+	// returned to
+	WithdrawAddress(ctx context.Context, in *DelegatorRequest, opts ...grpc.CallOption) (*ActorResponse, error)
+	// BuildDeclareCandidacy - build a tx declaring a candidate with a
+	// self-bond
+	BuildDeclareCandidacy(ctx context.Context, in *DeclareCandidacyRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// BuildEditCandidacy - build a tx editing the description of a candidate
+	BuildEditCandidacy(ctx context.Context, in *EditCandidacyRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// BuildDelegate - build a tx delegating coins to a candidate
+	BuildDelegate(ctx context.Context, in *DelegateRequest, opts ...grpc.CallOption) (*TxResponse, error)
+	// BuildUnbond - build a tx unbonding shares from a candidate
+	BuildUnbond(ctx context.Context, in *UnbondRequest, opts ...grpc.CallOption) (*TxResponse, error)
+}
+
+type stakeClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewStakeClient(cc *grpc.ClientConn) StakeClient {
+	return &stakeClient{cc}
+}
+
+func (c *stakeClient) Candidate(ctx context.Context, in *CandidateRequest, opts ...grpc.CallOption) (*CandidateResponse, error) {
+	out := new(CandidateResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/Candidate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) Candidates(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*PubKeysResponse, error) {
+	out := new(PubKeysResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/Candidates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) DelegatorBond(ctx context.Context, in *DelegatorBondRequest, opts ...grpc.CallOption) (*DelegatorBondResponse, error) {
+	out := new(DelegatorBondResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/DelegatorBond", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) DelegatorCandidates(ctx context.Context, in *DelegatorRequest, opts ...grpc.CallOption) (*PubKeysResponse, error) {
+	out := new(PubKeysResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/DelegatorCandidates", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) UpgradePlan(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*UpgradePlanResponse, error) {
+	out := new(UpgradePlanResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/UpgradePlan", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) Epoch(ctx context.Context, in *HeightRequest, opts ...grpc.CallOption) (*EpochResponse, error) {
+	out := new(EpochResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/Epoch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) WithdrawAddress(ctx context.Context, in *DelegatorRequest, opts ...grpc.CallOption) (*ActorResponse, error) {
+	out := new(ActorResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/WithdrawAddress", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) BuildDeclareCandidacy(ctx context.Context, in *DeclareCandidacyRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	out := new(TxResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/BuildDeclareCandidacy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) BuildEditCandidacy(ctx context.Context, in *EditCandidacyRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	out := new(TxResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/BuildEditCandidacy", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) BuildDelegate(ctx context.Context, in *DelegateRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	out := new(TxResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/BuildDelegate", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stakeClient) BuildUnbond(ctx context.Context, in *UnbondRequest, opts ...grpc.CallOption) (*TxResponse, error) {
+	out := new(TxResponse)
+	err := grpc.Invoke(ctx, "/rpc.Stake/BuildUnbond", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Stake service
+
+type StakeServer interface {
+	// Candidate - the candidate of a pubkey
+	Candidate(context.Context, *CandidateRequest) (*CandidateResponse, error)
+	// Candidates - the pubkeys of all the candidates
+	Candidates(context.Context, *HeightRequest) (*PubKeysResponse, error)
+	// DelegatorBond - the bond of a delegator to a candidate
+	DelegatorBond(context.Context, *DelegatorBondRequest) (*DelegatorBondResponse, error)
+	// DelegatorCandidates - the pubkeys of the candidates a delegator is
+	// bonded to
+	DelegatorCandidates(context.Context, *DelegatorRequest) (*PubKeysResponse, error)
+	// UpgradePlan - the scheduled software upgrade
+	UpgradePlan(context.Context, *HeightRequest) (*UpgradePlanResponse, error)
+	// Epoch - the current epoch
+	Epoch(context.Context, *HeightRequest) (*EpochResponse, error)
+	// WithdrawAddress - the account the unbonded coins of a delegator are
+	// returned to
+	WithdrawAddress(context.Context, *DelegatorRequest) (*ActorResponse, error)
+	// BuildDeclareCandidacy - build a tx declaring a candidate with a
+	// self-bond
+	BuildDeclareCandidacy(context.Context, *DeclareCandidacyRequest) (*TxResponse, error)
+	// BuildEditCandidacy - build a tx editing the description of a candidate
+	BuildEditCandidacy(context.Context, *EditCandidacyRequest) (*TxResponse, error)
+	// BuildDelegate - build a tx delegating coins to a candidate
+	BuildDelegate(context.Context, *DelegateRequest) (*TxResponse, error)
+	// BuildUnbond - build a tx unbonding shares from a candidate
+	BuildUnbond(context.Context, *UnbondRequest) (*TxResponse, error)
+}
+
+func RegisterStakeServer(s *grpc.Server, srv StakeServer) {
+	s.RegisterService(&_Stake_serviceDesc, srv)
+}
+
+func _Stake_Candidate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CandidateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).Candidate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/Candidate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).Candidate(ctx, req.(*CandidateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_Candidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).Candidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/Candidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).Candidates(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_DelegatorBond_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegatorBondRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).DelegatorBond(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/DelegatorBond",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).DelegatorBond(ctx, req.(*DelegatorBondRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_DelegatorCandidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).DelegatorCandidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/DelegatorCandidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).DelegatorCandidates(ctx, req.(*DelegatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_UpgradePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).UpgradePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/UpgradePlan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).UpgradePlan(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_Epoch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeightRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).Epoch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/Epoch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).Epoch(ctx, req.(*HeightRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_WithdrawAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegatorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).WithdrawAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/WithdrawAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).WithdrawAddress(ctx, req.(*DelegatorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_BuildDeclareCandidacy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclareCandidacyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).BuildDeclareCandidacy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/BuildDeclareCandidacy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).BuildDeclareCandidacy(ctx, req.(*DeclareCandidacyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_BuildEditCandidacy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditCandidacyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).BuildEditCandidacy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/BuildEditCandidacy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).BuildEditCandidacy(ctx, req.(*EditCandidacyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_BuildDelegate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DelegateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).BuildDelegate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/BuildDelegate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).BuildDelegate(ctx, req.(*DelegateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stake_BuildUnbond_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbondRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StakeServer).BuildUnbond(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.Stake/BuildUnbond",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StakeServer).BuildUnbond(ctx, req.(*UnbondRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Stake_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "rpc.Stake",
+	HandlerType: (*StakeServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Candidate",
+			Handler:    _Stake_Candidate_Handler,
+		},
+		{
+			MethodName: "Candidates",
+			Handler:    _Stake_Candidates_Handler,
+		},
+		{
+			MethodName: "DelegatorBond",
+			Handler:    _Stake_DelegatorBond_Handler,
+		},
+		{
+			MethodName: "DelegatorCandidates",
+			Handler:    _Stake_DelegatorCandidates_Handler,
+		},
+		{
+			MethodName: "UpgradePlan",
+			Handler:    _Stake_UpgradePlan_Handler,
+		},
+		{
+			MethodName: "Epoch",
+			Handler:    _Stake_Epoch_Handler,
+		},
+		{
+			MethodName: "WithdrawAddress",
+			Handler:    _Stake_WithdrawAddress_Handler,
+		},
+		{
+			MethodName: "BuildDeclareCandidacy",
+			Handler:    _Stake_BuildDeclareCandidacy_Handler,
+		},
+		{
+			MethodName: "BuildEditCandidacy",
+			Handler:    _Stake_BuildEditCandidacy_Handler,
+		},
+		{
+			MethodName: "BuildDelegate",
+			Handler:    _Stake_BuildDelegate_Handler,
+		},
+		{
+			MethodName: "BuildUnbond",
+			Handler:    _Stake_BuildUnbond_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "stake.proto",
+}
+
+func init() { proto.RegisterFile("stake.proto", fileDescriptor0) }
+
+var fileDescriptor0 = []byte{
+	// 1091 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x5f, 0x6f, 0xdb, 0x36,
+	0x10, 0x87, 0x2d, 0x3b, 0xb1, 0x4f, 0x76, 0x93, 0xb1, 0x49, 0xab, 0x04, 0xed, 0xe6, 0x6a, 0x43,
+	0x9b, 0x87, 0x21, 0x05, 0xb2, 0xad, 0xc0, 0xfe, 0x3c, 0xb4, 0x49, 0x3a, 0xac, 0x18, 0xb0, 0x06,
+	0x4a, 0x8a, 0x62, 0x4f, 0x06, 0x2d, 0xb2, 0xb6, 0x16, 0x99, 0x54, 0x45, 0x7a, 0x76, 0x3e, 0xc4,
+	0x80, 0x7d, 0x8d, 0x01, 0x7b, 0xdb, 0x17, 0x1c, 0xf8, 0x47, 0xb2, 0x24, 0x4b, 0x41, 0x87, 0x61,
+	0x7b, 0xf3, 0xdd, 0xfd, 0xf8, 0xbb, 0x1f, 0x79, 0xc7, 0xa3, 0x0c, 0xae, 0x90, 0xf8, 0x9a, 0x1e,
+	0x27, 0x29, 0x97, 0x1c, 0x39, 0x69, 0x12, 0xfa, 0x3f, 0x41, 0xf7, 0x45, 0x28, 0x79, 0x8a, 0x0e,
+	0xa0, 0x17, 0xce, 0x70, 0xc4, 0xc6, 0x11, 0xf1, 0x5a, 0xa3, 0xd6, 0x51, 0x3f, 0xd8, 0xd6, 0xf6,
+	0x2b, 0x82, 0x76, 0xc1, 0xc1, 0x49, 0xe2, 0xb5, 0xb5, 0x57, 0xfd, 0x44, 0x1e, 0x6c, 0x63, 0x42,
+	0x52, 0x2a, 0x84, 0xe7, 0x8c, 0x5a, 0x47, 0x83, 0x20, 0x33, 0xfd, 0x2f, 0xa1, 0x73, 0xc6, 0x23,
+	0x86, 0xf6, 0xa0, 0x4b, 0x28, 0xe3, 0x73, 0xcb, 0x65, 0x0c, 0x74, 0x0f, 0xb6, 0xf0, 0x9c, 0x2f,
+	0x98, 0xd4, 0x64, 0x4e, 0x60, 0x2d, 0x7f, 0x09, 0xee, 0x39, 0x15, 0x61, 0x1a, 0x25, 0x32, 0xe2,
+	0x4c, 0xd1, 0xcf, 0x39, 0x8b, 0xae, 0x69, 0x9a, 0x49, 0xb1, 0x26, 0x3a, 0x84, 0x5e, 0x44, 0x28,
+	0x93, 0x91, 0xbc, 0xb1, 0x7a, 0x72, 0x5b, 0xad, 0x5a, 0xd2, 0x89, 0x88, 0x24, 0xd5, 0xa2, 0xfa,
+	0x41, 0x66, 0xaa, 0x08, 0xa1, 0x12, 0x47, 0xb1, 0xf0, 0x3a, 0x26, 0x62, 0x4d, 0x95, 0xf8, 0xf5,
+	0x92, 0xd1, 0xf4, 0x6c, 0x86, 0xd9, 0x94, 0x2a, 0x7d, 0x33, 0x1a, 0x4d, 0x67, 0x52, 0xe7, 0x75,
+	0x02, 0x6b, 0xa1, 0x27, 0xd0, 0xe7, 0x31, 0x19, 0x73, 0x05, 0xd5, 0x79, 0xdd, 0x13, 0x38, 0x4e,
+	0x93, 0xf0, 0x58, 0x9f, 0x5d, 0xd0, 0xe3, 0x31, 0xd1, 0x34, 0x0a, 0xc8, 0xe8, 0xd2, 0x02, 0x9d,
+	0x4d, 0x20, 0xa3, 0x4b, 0x0d, 0xf4, 0xff, 0x74, 0xa0, 0x7f, 0x86, 0x19, 0x89, 0x08, 0x96, 0x14,
+	0xdd, 0x87, 0xed, 0x64, 0x31, 0x19, 0x5f, 0xd3, 0x1b, 0x9d, 0x78, 0x10, 0x6c, 0x25, 0x8b, 0xc9,
+	0x8f, 0xf4, 0x06, 0x8d, 0xa0, 0xdb, 0x94, 0xd4, 0x04, 0x94, 0x64, 0x31, 0xc3, 0x29, 0x35, 0x95,
+	0xe8, 0x04, 0xd6, 0x42, 0x8f, 0x60, 0xf0, 0x2b, 0x97, 0x11, 0x9b, 0x8e, 0x13, 0xbe, 0xa4, 0xa9,
+	0xde, 0x78, 0x27, 0x70, 0x8d, 0xef, 0x42, 0xb9, 0x14, 0x44, 0xe2, 0x74, 0x4a, 0xa5, 0x85, 0x74,
+	0x0d, 0xc4, 0xf8, 0x0c, 0xe4, 0x04, 0x5c, 0xb2, 0x2e, 0x8c, 0xb7, 0xa5, 0x55, 0xec, 0x6a, 0x15,
+	0x85, 0x82, 0x05, 0x45, 0x10, 0x7a, 0x0a, 0xc3, 0x84, 0x32, 0xa2, 0x52, 0x1b, 0xed, 0xdb, 0x1b,
+	0xda, 0x07, 0x16, 0x60, 0x0e, 0xed, 0x2b, 0x18, 0x6a, 0xe0, 0x38, 0xd4, 0x55, 0x10, 0x5e, 0x6f,
+	0xe4, 0xe4, 0x69, 0x0a, 0xe5, 0x09, 0x06, 0x7c, 0x6d, 0x08, 0xf4, 0x18, 0x7a, 0x3c, 0xa1, 0x29,
+	0x96, 0x3c, 0xf5, 0xfa, 0x35, 0x35, 0xb1, 0x31, 0xf4, 0x29, 0x0c, 0x13, 0xbc, 0x10, 0x94, 0x8c,
+	0x6d, 0x6d, 0x41, 0xd7, 0x76, 0x60, 0x9c, 0x3f, 0x98, 0x0a, 0x3f, 0x04, 0x98, 0xe3, 0xd5, 0xd8,
+	0x1e, 0xa5, 0xab, 0x4f, 0xa2, 0x3f, 0xc7, 0xab, 0x4b, 0xed, 0xf0, 0x9f, 0xc3, 0xf0, 0x9c, 0xc6,
+	0x74, 0xaa, 0x08, 0x4f, 0x39, 0x23, 0xcd, 0x15, 0x5b, 0xd7, 0xa3, 0x5d, 0xac, 0x87, 0xff, 0x35,
+	0xb8, 0x6f, 0x92, 0x69, 0x8a, 0x09, 0xbd, 0x88, 0x31, 0x43, 0x08, 0x3a, 0x0c, 0xcf, 0xa9, 0xed,
+	0x6f, 0xfd, 0xbb, 0xd0, 0x7d, 0xed, 0x62, 0xf7, 0xf9, 0x21, 0x74, 0x5f, 0x26, 0x3c, 0x9c, 0x29,
+	0x00, 0x5b, 0xcc, 0x27, 0xf6, 0x5a, 0x38, 0x81, 0xb5, 0x54, 0x21, 0x85, 0xc4, 0xa9, 0x1c, 0x97,
+	0x96, 0xbb, 0xda, 0x67, 0xf7, 0xf7, 0x09, 0xb8, 0x8c, 0xae, 0x72, 0x84, 0xa3, 0x11, 0xa0, 0x5c,
+	0x06, 0xe0, 0x3f, 0x81, 0xa1, 0xf9, 0x15, 0xd0, 0xf7, 0x0b, 0x2a, 0x64, 0xd3, 0x5d, 0xf0, 0xcf,
+	0x60, 0x37, 0x6f, 0xdc, 0x0c, 0x7b, 0xdb, 0x69, 0xd4, 0x6e, 0xe9, 0x0a, 0x76, 0xf3, 0xf3, 0xcc,
+	0x48, 0x8e, 0xa0, 0x4f, 0x32, 0x9f, 0xd7, 0xda, 0x28, 0xe8, 0x3a, 0xd8, 0xc8, 0xfa, 0x1e, 0xf6,
+	0x4a, 0x55, 0xfa, 0xe7, 0xcc, 0x85, 0x8d, 0xb4, 0x1b, 0x36, 0xe2, 0x94, 0x52, 0xfe, 0x0c, 0x1f,
+	0x15, 0x4e, 0x43, 0x24, 0x9c, 0x09, 0x8a, 0x3e, 0x87, 0x7e, 0x98, 0x39, 0x6d, 0xbe, 0x3b, 0x3a,
+	0xdf, 0x1a, 0xba, 0x06, 0x34, 0xee, 0xe6, 0x1c, 0x76, 0x2e, 0x74, 0x72, 0x91, 0x13, 0x1f, 0x40,
+	0xcf, 0xca, 0x13, 0x5e, 0x6b, 0xe4, 0xa8, 0xc1, 0x6b, 0xf4, 0x89, 0x46, 0x96, 0xb7, 0xb0, 0x5f,
+	0x39, 0x13, 0xcb, 0xf5, 0x18, 0x3a, 0x13, 0xce, 0x88, 0xd5, 0x87, 0xec, 0x9d, 0x2e, 0x22, 0x75,
+	0xbc, 0x91, 0xf8, 0x12, 0xee, 0x16, 0x1a, 0x3a, 0xa7, 0xfd, 0x0c, 0x3a, 0x49, 0x8c, 0x99, 0xa5,
+	0x35, 0x77, 0xb8, 0x88, 0xd3, 0xd1, 0x46, 0xd2, 0x57, 0x30, 0xd4, 0xad, 0x9e, 0xd3, 0x8d, 0xa0,
+	0x4b, 0x95, 0xa3, 0x54, 0x36, 0x03, 0x31, 0x81, 0xdb, 0xa8, 0x4c, 0x79, 0x0b, 0x54, 0x38, 0xac,
+	0xef, 0x00, 0x13, 0x68, 0xa4, 0xfa, 0xbd, 0x05, 0xfd, 0xab, 0xd5, 0x6b, 0x3d, 0xde, 0x04, 0x7a,
+	0x08, 0x9d, 0x77, 0x94, 0x0a, 0x4b, 0xd3, 0x37, 0x85, 0xe5, 0x11, 0x0b, 0xb4, 0x1b, 0x7d, 0x0c,
+	0x9d, 0x77, 0x29, 0x9f, 0xd7, 0x4c, 0x6c, 0xed, 0x57, 0x4f, 0x98, 0x50, 0x7d, 0xc9, 0x42, 0xf3,
+	0x4e, 0x0d, 0x83, 0xdc, 0x56, 0x53, 0x21, 0xe5, 0x31, 0xd5, 0xc3, 0x7a, 0x10, 0xe8, 0xdf, 0xea,
+	0x25, 0x9d, 0x2f, 0x62, 0x19, 0xe9, 0xf1, 0xdc, 0x0b, 0x8c, 0xe1, 0xff, 0xd5, 0x82, 0xfb, 0xe7,
+	0x34, 0x8c, 0x71, 0x4a, 0x6d, 0x53, 0x85, 0x37, 0xeb, 0x76, 0xdf, 0xe6, 0x46, 0x6b, 0xa9, 0xf9,
+	0xf2, 0x1d, 0x04, 0x59, 0xb8, 0xb9, 0xdd, 0x1f, 0xe5, 0x0f, 0xb5, 0x53, 0xdd, 0xa5, 0x0d, 0x54,
+	0x9f, 0x86, 0xce, 0x07, 0x3c, 0x0d, 0xfe, 0x6f, 0x2d, 0xd8, 0x7b, 0x49, 0x22, 0xf9, 0x5f, 0x48,
+	0xae, 0xe8, 0x71, 0x3e, 0x44, 0xcf, 0x12, 0x76, 0x6c, 0xcb, 0xd3, 0xff, 0xf5, 0xf0, 0xfc, 0x5f,
+	0x60, 0xf8, 0x86, 0x4d, 0x4a, 0x23, 0xea, 0x5f, 0xa7, 0x6d, 0xf8, 0x12, 0xf0, 0x1f, 0x00, 0x5c,
+	0xad, 0xf2, 0x5b, 0x70, 0x07, 0xda, 0x72, 0x65, 0xa7, 0x74, 0x5b, 0xae, 0x4e, 0xfe, 0xe8, 0x42,
+	0xf7, 0x52, 0x7d, 0x15, 0xa2, 0x6f, 0x8a, 0x5f, 0x24, 0xfb, 0x95, 0x79, 0x65, 0x64, 0x1e, 0xde,
+	0xab, 0xba, 0x2d, 0xeb, 0x33, 0x80, 0xdc, 0x29, 0x90, 0x19, 0x26, 0xa5, 0xe7, 0xe4, 0x70, 0x4f,
+	0xfb, 0xaa, 0x03, 0xed, 0xfb, 0xea, 0xbb, 0x7a, 0x50, 0x33, 0x87, 0x2c, 0xc3, 0x61, 0x5d, 0xc8,
+	0xf2, 0x9c, 0xc2, 0xdd, 0x3c, 0x50, 0x10, 0xb2, 0x5f, 0x5e, 0x72, 0xbb, 0x96, 0x6f, 0x2b, 0x2f,
+	0x74, 0xcd, 0x26, 0xbc, 0x8d, 0x71, 0x96, 0x2d, 0x7e, 0x9a, 0xbd, 0xd1, 0x75, 0xcb, 0x50, 0x61,
+	0x6a, 0x65, 0x0b, 0xbe, 0x83, 0x9d, 0xb7, 0x91, 0x9c, 0x91, 0x14, 0x2f, 0x5f, 0x98, 0x6f, 0xe7,
+	0x26, 0xb5, 0xa8, 0x30, 0x42, 0xd6, 0xe7, 0xb6, 0x7f, 0xba, 0x88, 0x62, 0x52, 0x1d, 0x01, 0xe8,
+	0x81, 0xe5, 0xa8, 0x9d, 0x0c, 0x87, 0x3b, 0xb6, 0xa9, 0x72, 0x9e, 0xe7, 0x80, 0x34, 0x4f, 0xe9,
+	0x52, 0xda, 0x22, 0xd4, 0x5d, 0xd4, 0x4d, 0x86, 0x67, 0x30, 0xb4, 0x4a, 0xcc, 0x3d, 0x42, 0x7b,
+	0xc5, 0x5d, 0xd0, 0xc6, 0x75, 0x27, 0xe0, 0xea, 0x75, 0xe6, 0x1a, 0xd8, 0x63, 0x2b, 0xdd, 0x89,
+	0x8d, 0x35, 0x93, 0x2d, 0xfd, 0xc7, 0xe5, 0x8b, 0xbf, 0x07, 0x00, 0xf1, 0x14, 0x1e, 0xdb, 0xc7,
+	0x0c, 0x00, 0x00,
+}
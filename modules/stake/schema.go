@@ -0,0 +1,84 @@
+package stake
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// SchemaVersion - the version of the layout of the stake store written by
+// this software. It must be incremented whenever a stored type changes
+// shape, with a migration registered from the previous version. Version 0
+// is the layout of the 0.5 release, which did not record its version.
+const SchemaVersion int64 = 1
+
+var schemaMigrations = make(map[int64]Migration)
+
+// RegisterSchemaMigration - register the migration of the stake store from
+// the layout of version from to the layout of version from+1
+func RegisterSchemaMigration(from int64, migration Migration) {
+	if from < 0 || from >= SchemaVersion {
+		panic(fmt.Sprintf("no stake schema migration can start from version %d", from))
+	}
+	if _, ok := schemaMigrations[from]; ok {
+		panic(fmt.Sprintf("stake schema migration already registered from version %d", from))
+	}
+	schemaMigrations[from] = migration
+}
+
+// SchemaMigrations - the versions the stake store would be migrated from,
+// in the order the migrations are run
+func SchemaMigrations(store state.SimpleDB) (from []int64, err error) {
+	version := loadSchemaVersion(store)
+	if version > SchemaVersion {
+		return nil, ErrSchemaTooNew(version)
+	}
+	if version == 0 && isNewStore(store) {
+		return nil, nil
+	}
+	for v := version; v < SchemaVersion; v++ {
+		if _, ok := schemaMigrations[v]; !ok {
+			return nil, fmt.Errorf("no stake schema migration registered from version %d", v)
+		}
+		from = append(from, v)
+	}
+	return from, nil
+}
+
+// MigrateSchema - migrate the stake store to the layout of this software.
+// It is run at the beginning of each block, before the txs and the tick read
+// the store, so the first block processed by a new binary, either after a
+// coordinated restart or at the halt height of an upgrade plan, migrates the
+// store as part of the consensus. A new store is written in the current
+// layout, the genesis records its version.
+func MigrateSchema(ctx sdk.Context, store state.SimpleDB) error {
+	version := loadSchemaVersion(store)
+	if version == SchemaVersion {
+		return nil
+	}
+	if version == 0 && isNewStore(store) {
+		saveSchemaVersion(store, SchemaVersion)
+		return nil
+	}
+
+	versions, err := SchemaMigrations(store)
+	if err != nil {
+		return err
+	}
+	for _, v := range versions {
+		err := schemaMigrations[v](store)
+		if err != nil {
+			return fmt.Errorf("stake schema migration from version %d failed: %v", v, err)
+		}
+		saveSchemaVersion(store, v+1)
+		ctx.Info("Stake schema migrated", "from", v, "to", v+1)
+	}
+	return nil
+}
+
+// isNewStore - whether an unversioned store holds nothing yet, the store of
+// the release always holds its params or its candidates
+func isNewStore(store state.SimpleDB) bool {
+	return !store.Has(ParamKey) && !store.Has(CandidatesPubKeysKey)
+}
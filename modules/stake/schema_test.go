@@ -0,0 +1,112 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/go-wire"
+	"github.com/tendermint/tmlibs/log"
+
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+func TestMigrateSchema(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	ctx := stack.NewContext("testChain", 1, log.NewNopLogger())
+
+	// a new store is written in the current layout
+	versions, err := SchemaMigrations(store)
+	require.NoError(err)
+	assert.Zero(len(versions))
+	require.NoError(MigrateSchema(ctx, store))
+	assert.Equal(SchemaVersion, loadSchemaVersion(store))
+
+	// nothing to do once up to date
+	require.NoError(MigrateSchema(ctx, store))
+	assert.Equal(SchemaVersion, loadSchemaVersion(store))
+
+	// a store written by newer software halts the node
+	saveSchemaVersion(store, SchemaVersion+1)
+	_, err = SchemaMigrations(store)
+	assert.True(IsUpgradeNeededErr(err), "%v", err)
+	err = MigrateSchema(ctx, store)
+	assert.True(IsUpgradeNeededErr(err), "%v", err)
+
+	// migrations can only be registered up to the current version
+	assert.Panics(func() { RegisterSchemaMigration(-1, nil) })
+	assert.Panics(func() { RegisterSchemaMigration(0, nil) })
+	assert.Panics(func() { RegisterSchemaMigration(SchemaVersion, nil) })
+}
+
+func TestMigrateSchemaV0(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(3, 1000)
+	ctx := stack.NewContext("testChain", 1, log.NewNopLogger())
+	owner := senders[0]
+
+	// the bonds keep their layout, the candidates and params are written as
+	// by the release, which did not record the version nor index and count
+	// delegators
+	deliverer := newDeliver(owner, accStore)
+	store := deliverer.store
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	for _, delegator := range senders[1:] {
+		deliverer.sender = delegator
+		require.NoError(deliverer.delegate(newTxDelegate(10, pk1)))
+	}
+	store.Remove(GetDelegatorCountKey(pk1))
+	for _, delegator := range senders {
+		store.Remove(GetCandidateDelegatorKey(pk1, delegator))
+	}
+	require.Empty(loadCandidateDelegators(store, pk1))
+	old := candidateV0{pk1, owner, 30, 30, Description{Moniker: "val"}}
+	store.Set(GetCandidateKey(pk1), wire.BinaryBytes(old))
+	oldParams := paramsV0{owner, 7, "atom", 1, 2, 3, 4}
+	store.Set(ParamKey, wire.BinaryBytes(oldParams))
+
+	versions, err := SchemaMigrations(store)
+	require.NoError(err)
+	assert.Equal([]int64{0}, versions)
+	require.NoError(MigrateSchema(ctx, store))
+	assert.Equal(SchemaVersion, loadSchemaVersion(store))
+
+	candidate := loadCandidate(store, pk1)
+	require.NotNil(candidate)
+	assert.Equal(owner, candidate.Owner)
+	assert.Equal(uint64(30), candidate.Shares)
+	assert.Equal(uint64(30), candidate.TargetPower)
+	assert.Equal("val", candidate.Description.Moniker)
+	assert.Nil(candidate.Operator)
+	assert.Zero(candidate.MaxShares)
+	assert.Equal(int64(3), loadDelegatorCount(store, pk1))
+	assert.ElementsMatch(senders, loadCandidateDelegators(store, pk1))
+
+	// the new params get their default value
+	params := loadParams(store)
+	assert.Equal(uint16(7), params.MaxVals)
+	assert.Equal("atom", params.AllowedBondDenom)
+	assert.Equal(int64(4), params.GasUnbond)
+	assert.Equal(defaultParams().UpgradeDelay, params.UpgradeDelay)
+	assert.Equal(defaultParams().GasRevokeCandidacy, params.GasRevokeCandidacy)
+	assert.Equal(int64(1), params.EpochLength)
+	assert.Equal(ElectionTopN, params.ElectionPolicy)
+}
+
+func TestGenesisSchemaVersion(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	store := state.NewMemKVStore()
+	ctx := stack.NewContext("testChain", 1, log.NewNopLogger())
+
+	// the params of the genesis are in the current layout
+	require.NoError(NewHandler().initState(stakingModuleName, "max_vals", "7", store))
+	assert.Equal(SchemaVersion, loadSchemaVersion(store))
+	versions, err := SchemaMigrations(store)
+	require.NoError(err)
+	assert.Zero(len(versions))
+	require.NoError(MigrateSchema(ctx, store))
+	assert.Equal(uint16(7), loadParams(store).MaxVals)
+}
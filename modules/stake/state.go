@@ -18,6 +18,24 @@ var (
 	CandidateKeyPrefix      = []byte{0x03} // prefix for each key to a candidate
 	DelegatorBondKeyPrefix  = []byte{0x04} // prefix for each key to a delegator's bond
 	DelegatorBondsKeyPrefix = []byte{0x05} // prefix for each key to a delegator's bond
+	UpgradeSignalKeyPrefix  = []byte{0x06} // prefix for each key to a candidate's upgrade signal
+
+	UpgradePlanKey   = []byte{0x07} // key for the scheduled upgrade plan
+	SchemaVersionKey = []byte{0x08} // key for the version of the layout of the stake store
+	KeyRotationsKey  = []byte{0x09} // key for the consensus key rotations since the validator set update
+
+	WithdrawAddressKeyPrefix = []byte{0x0A} // prefix for each key to a delegator's withdraw address
+	RemovedValidatorsKey     = []byte{0x0B} // key for the validators removed since the validator set update
+
+	EpochKey       = []byte{0x0C} // key for the current epoch
+	QueuedBondsKey = []byte{0x0D} // key for the delegations and unbonds of the current epoch
+
+	ElectionSeedKey = []byte{0x0E} // key for the seed of the election of the validators, the hash of the block
+
+	DelegatorCountKeyPrefix     = []byte{0x0F} // prefix for each key to the number of delegators of a candidate
+	CandidateDelegatorKeyPrefix = []byte{0x10} // prefix for each key to a delegator bonded to a candidate
+
+	HookEventsKey = []byte{0x11} // key for the staking events of the block, for the hooks
 )
 
 // GetCandidateKey - get the key for the candidate with pubKey
@@ -25,6 +43,29 @@ func GetCandidateKey(pubKey crypto.PubKey) []byte {
 	return append(CandidateKeyPrefix, pubKey.Bytes()...)
 }
 
+// GetUpgradeSignalKey - get the key for the upgrade signalled by a candidate
+func GetUpgradeSignalKey(pubKey crypto.PubKey) []byte {
+	return append(UpgradeSignalKeyPrefix, pubKey.Bytes()...)
+}
+
+// GetDelegatorCountKey - get the key for the number of delegators bonded to
+// the candidate with pubKey
+func GetDelegatorCountKey(pubKey crypto.PubKey) []byte {
+	return append(DelegatorCountKeyPrefix, pubKey.Bytes()...)
+}
+
+// GetCandidateDelegatorKey - get the key indexing a delegator bonded to the
+// candidate with pubKey
+func GetCandidateDelegatorKey(pubKey crypto.PubKey, delegator sdk.Actor) []byte {
+	return append(GetCandidateDelegatorsKeyPrefix(pubKey), wire.BinaryBytes(&delegator)...)
+}
+
+// GetCandidateDelegatorsKeyPrefix - get the prefix for all the delegators
+// bonded to the candidate with pubKey
+func GetCandidateDelegatorsKeyPrefix(pubKey crypto.PubKey) []byte {
+	return append(CandidateDelegatorKeyPrefix, pubKey.Bytes()...)
+}
+
 // GetDelegatorBondKey - get the key for delegator bond with candidate
 func GetDelegatorBondKey(delegator sdk.Actor, candidate crypto.PubKey) []byte {
 	return append(GetDelegatorBondKeyPrefix(delegator), candidate.Bytes()...)
@@ -40,6 +81,11 @@ func GetDelegatorBondsKey(delegator sdk.Actor) []byte {
 	return append(DelegatorBondsKeyPrefix, wire.BinaryBytes(&delegator)...)
 }
 
+// GetWithdrawAddressKey - get the key for the withdraw address of a delegator
+func GetWithdrawAddressKey(delegator sdk.Actor) []byte {
+	return append(WithdrawAddressKeyPrefix, wire.BinaryBytes(&delegator)...)
+}
+
 //---------------------------------------------------------------------
 
 // Get the active list of all the candidate pubKeys and owners
@@ -84,6 +130,9 @@ func loadCandidate(store state.SimpleDB, pubKey crypto.PubKey) *Candidate {
 	if err != nil {
 		panic(err) // This error should never occure big problem if does
 	}
+	if len(candidate.OwnerChanges) == 0 {
+		candidate.OwnerChanges = nil // decoded as an empty slice
+	}
 	return candidate
 }
 
@@ -101,6 +150,8 @@ func saveCandidate(store state.SimpleDB, candidate *Candidate) {
 
 func removeCandidate(store state.SimpleDB, pubKey crypto.PubKey) {
 	store.Remove(GetCandidateKey(pubKey))
+	store.Remove(GetUpgradeSignalKey(pubKey))
+	store.Remove(GetDelegatorCountKey(pubKey))
 
 	// TODO to be replaced with iteration in the multistore?
 	pks := loadCandidatesPubKeys(store)
@@ -115,6 +166,76 @@ func removeCandidate(store state.SimpleDB, pubKey crypto.PubKey) {
 
 //---------------------------------------------------------------------
 
+// keyRotation - a candidate which changed its consensus key during the block
+type keyRotation struct {
+	OldPubKey crypto.PubKey
+	NewPubKey crypto.PubKey
+}
+
+func loadKeyRotations(store state.SimpleDB) (rotations []keyRotation) {
+	b := store.Get(KeyRotationsKey)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &rotations)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// record a rotation, a key rotated twice between two validator set updates
+// keeps its first key
+func saveKeyRotation(store state.SimpleDB, oldPubKey, newPubKey crypto.PubKey) {
+	rotations := loadKeyRotations(store)
+	found := false
+	for i := range rotations {
+		if rotations[i].NewPubKey.Equals(oldPubKey) {
+			rotations[i].NewPubKey = newPubKey
+			found = true
+		}
+	}
+	if !found {
+		rotations = append(rotations, keyRotation{oldPubKey, newPubKey})
+	}
+	store.Set(KeyRotationsKey, wire.BinaryBytes(rotations))
+}
+
+func removeKeyRotations(store state.SimpleDB) {
+	store.Remove(KeyRotationsKey)
+}
+
+//---------------------------------------------------------------------
+
+// validators removed since the validator set update, the next update removes
+// them from the set of Tendermint
+func loadRemovedValidators(store state.SimpleDB) (validators Validators) {
+	b := store.Get(RemovedValidatorsKey)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &validators)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+// record the removal of a candidate which is in the validator set
+func saveRemovedValidator(store state.SimpleDB, candidate *Candidate) {
+	if candidate.VotingPower == 0 {
+		return
+	}
+	validators := append(loadRemovedValidators(store), candidate.validator())
+	store.Set(RemovedValidatorsKey, wire.BinaryBytes(validators))
+}
+
+func removeRemovedValidators(store state.SimpleDB) {
+	store.Remove(RemovedValidatorsKey)
+}
+
+//---------------------------------------------------------------------
+
 // load the pubkeys of all candidates a delegator is delegated too
 func loadDelegatorCandidates(store state.SimpleDB,
 	delegator sdk.Actor) (candidates []crypto.PubKey) {
@@ -151,12 +272,15 @@ func loadDelegatorBond(store state.SimpleDB,
 
 func saveDelegatorBond(store state.SimpleDB, delegator sdk.Actor, bond *DelegatorBond) {
 
-	// if a new bond add to the list of bonds
+	// if a new bond add to the list of bonds, and index and count the
+	// delegator of the candidate
 	if loadDelegatorBond(store, delegator, bond.PubKey) == nil {
 		pks := loadDelegatorCandidates(store, delegator)
 		pks = append(pks, (*bond).PubKey)
 		b := wire.BinaryBytes(pks)
 		store.Set(GetDelegatorBondsKey(delegator), b)
+		store.Set(GetCandidateDelegatorKey(bond.PubKey, delegator), wire.BinaryBytes(delegator))
+		saveDelegatorCount(store, bond.PubKey, loadDelegatorCount(store, bond.PubKey)+1)
 	}
 
 	// now actually save the bond
@@ -179,10 +303,87 @@ func removeDelegatorBond(store state.SimpleDB, delegator sdk.Actor, candidate cr
 	store.Set(GetDelegatorBondsKey(delegator), b)
 
 	// now remove the actual bond
-	store.Remove(GetDelegatorBondKey(delegator, candidate))
+	if store.Has(GetDelegatorBondKey(delegator, candidate)) {
+		store.Remove(GetDelegatorBondKey(delegator, candidate))
+		store.Remove(GetCandidateDelegatorKey(candidate, delegator))
+		saveDelegatorCount(store, candidate, loadDelegatorCount(store, candidate)-1)
+	}
 	//updateDelegatorBonds(store, delegator)
 }
 
+// load the number of delegators bonded to a candidate
+func loadDelegatorCount(store state.SimpleDB, candidate crypto.PubKey) (count int64) {
+	b := store.Get(GetDelegatorCountKey(candidate))
+	if b == nil {
+		return 0
+	}
+	err := wire.ReadBinaryBytes(b, &count)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveDelegatorCount(store state.SimpleDB, candidate crypto.PubKey, count int64) {
+	if count <= 0 {
+		store.Remove(GetDelegatorCountKey(candidate))
+		return
+	}
+	store.Set(GetDelegatorCountKey(candidate), wire.BinaryBytes(count))
+}
+
+// load the account the payouts of a delegator are sent to, the delegator
+// itself unless it set a withdraw address
+func loadWithdrawAddress(store state.SimpleDB, delegator sdk.Actor) sdk.Actor {
+	b := store.Get(GetWithdrawAddressKey(delegator))
+	if b == nil {
+		return delegator
+	}
+	var address sdk.Actor
+	err := wire.ReadBinaryBytes(b, &address)
+	if err != nil {
+		panic(err)
+	}
+	return address
+}
+
+func saveWithdrawAddress(store state.SimpleDB, delegator, address sdk.Actor) {
+	store.Set(GetWithdrawAddressKey(delegator), wire.BinaryBytes(address))
+}
+
+func removeWithdrawAddress(store state.SimpleDB, delegator sdk.Actor) {
+	store.Remove(GetWithdrawAddressKey(delegator))
+}
+
+// load the delegators bonded to a candidate from the index of its bonds
+func loadCandidateDelegators(store state.SimpleDB,
+	candidate crypto.PubKey) (delegators []sdk.Actor) {
+
+	prefix := GetCandidateDelegatorsKeyPrefix(candidate)
+	for _, model := range store.List(prefix, prefixEnd(prefix), 0) {
+		var delegator sdk.Actor
+		err := wire.ReadBinaryBytes(model.Value, &delegator)
+		if err != nil {
+			panic(err)
+		}
+		delegators = append(delegators, delegator)
+	}
+	return
+}
+
+// prefixEnd - the end of the range of the keys starting with prefix
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
 //func updateDelegatorBonds(store state.SimpleDB,
 //delegator sdk.Actor) {
 
@@ -218,6 +419,63 @@ func removeDelegatorBond(store state.SimpleDB, delegator sdk.Actor, candidate cr
 
 //---------------------------------------------------------------------
 
+// load/save the name of the upgrade signalled by a candidate
+func loadUpgradeSignal(store state.SimpleDB, pubKey crypto.PubKey) string {
+	b := store.Get(GetUpgradeSignalKey(pubKey))
+	if b == nil {
+		return ""
+	}
+	var name string
+	err := wire.ReadBinaryBytes(b, &name)
+	if err != nil {
+		panic(err)
+	}
+	return name
+}
+func saveUpgradeSignal(store state.SimpleDB, pubKey crypto.PubKey, name string) {
+	store.Set(GetUpgradeSignalKey(pubKey), wire.BinaryBytes(name))
+}
+
+// load/save/remove the scheduled upgrade plan
+func loadUpgradePlan(store state.SimpleDB) *UpgradePlan {
+	b := store.Get(UpgradePlanKey)
+	if b == nil {
+		return nil
+	}
+	plan := new(UpgradePlan)
+	err := wire.ReadBinaryBytes(b, plan)
+	if err != nil {
+		panic(err)
+	}
+	return plan
+}
+func saveUpgradePlan(store state.SimpleDB, plan UpgradePlan) {
+	store.Set(UpgradePlanKey, wire.BinaryBytes(plan))
+}
+func removeUpgradePlan(store state.SimpleDB) {
+	store.Remove(UpgradePlanKey)
+}
+
+//---------------------------------------------------------------------
+
+// load/save the schema version of the store, zero if it was never saved
+func loadSchemaVersion(store state.SimpleDB) (version int64) {
+	b := store.Get(SchemaVersionKey)
+	if b == nil {
+		return 0
+	}
+	err := wire.ReadBinaryBytes(b, &version)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+func saveSchemaVersion(store state.SimpleDB, version int64) {
+	store.Set(SchemaVersionKey, wire.BinaryBytes(version))
+}
+
+//---------------------------------------------------------------------
+
 // load/save the global staking params
 func loadParams(store state.SimpleDB) (params Params) {
 	b := store.Get(ParamKey)
@@ -236,3 +494,87 @@ func saveParams(store state.SimpleDB, params Params) {
 	b := wire.BinaryBytes(params)
 	store.Set(ParamKey, b)
 }
+
+//---------------------------------------------------------------------
+
+// the current epoch, the zero epoch ends at the first block
+func loadEpoch(store state.SimpleDB) (epoch Epoch) {
+	b := store.Get(EpochKey)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &epoch)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveEpoch(store state.SimpleDB, epoch Epoch) {
+	store.Set(EpochKey, wire.BinaryBytes(epoch))
+}
+
+func loadQueuedBonds(store state.SimpleDB) (queue QueuedBonds) {
+	b := store.Get(QueuedBondsKey)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &queue)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func saveQueuedBonds(store state.SimpleDB, queue QueuedBonds) {
+	store.Set(QueuedBondsKey, wire.BinaryBytes(queue))
+}
+
+func queueBond(store state.SimpleDB, queued QueuedBond) {
+	saveQueuedBonds(store, append(loadQueuedBonds(store), queued))
+}
+
+func removeQueuedBonds(store state.SimpleDB) {
+	store.Remove(QueuedBondsKey)
+}
+
+//---------------------------------------------------------------------
+
+func loadHookEvents(store state.SimpleDB) (events []hookEvent) {
+	b := store.Get(HookEventsKey)
+	if b == nil {
+		return
+	}
+	err := wire.ReadBinaryBytes(b, &events)
+	if err != nil {
+		panic(err)
+	}
+	return
+}
+
+func queueHookEvent(store state.SimpleDB, event hookEvent) {
+	store.Set(HookEventsKey, wire.BinaryBytes(append(loadHookEvents(store), event)))
+}
+
+func removeHookEvents(store state.SimpleDB) {
+	store.Remove(HookEventsKey)
+}
+
+//---------------------------------------------------------------------
+
+func loadElectionSeed(store state.SimpleDB) []byte {
+	return store.Get(ElectionSeedKey)
+}
+
+// SaveElectionSeed - record the hash of the block when the election policy
+// is seeded, it seeds the election of the validators at the end of the
+// block. The other policies keep no seed.
+func SaveElectionSeed(store state.SimpleDB, hash []byte) {
+	if _, ok := electionPolicy(loadParams(store)).(SeededElectionPolicy); !ok {
+		if store.Has(ElectionSeedKey) {
+			store.Remove(ElectionSeedKey)
+		}
+		return
+	}
+	store.Set(ElectionSeedKey, hash)
+}
@@ -75,6 +75,16 @@ func TestState(t *testing.T) {
 	resBond = loadDelegatorBond(store, delegator, pk)
 	assert.Equal(bond, resBond)
 
+	//the delegator is indexed and counted once for the candidate
+	assert.Equal([]sdk.Actor{delegator}, loadCandidateDelegators(store, pk))
+	assert.Equal(int64(1), loadDelegatorCount(store, pk))
+
+	//remove the record, the index is cleared
+	removeDelegatorBond(store, delegator, pk)
+	assert.Nil(loadDelegatorBond(store, delegator, pk))
+	assert.Empty(loadCandidateDelegators(store, pk))
+	assert.Zero(loadDelegatorCount(store, pk))
+
 	//----------------------------------------------------------------------
 	// Param checks
 
@@ -15,14 +15,34 @@ import (
 // make sure to use the name of the handler as the prefix in the tx type,
 // so it gets routed properly
 const (
-	ByteTxDeclareCandidacy = 0x55
-	ByteTxEditCandidacy    = 0x56
-	ByteTxDelegate         = 0x57
-	ByteTxUnbond           = 0x58
-	TypeTxDeclareCandidacy = stakingModuleName + "/declareCandidacy"
-	TypeTxEditCandidacy    = stakingModuleName + "/editCandidacy"
-	TypeTxDelegate         = stakingModuleName + "/delegate"
-	TypeTxUnbond           = stakingModuleName + "/unbond"
+	ByteTxDeclareCandidacy   = 0x55
+	ByteTxEditCandidacy      = 0x56
+	ByteTxDelegate           = 0x57
+	ByteTxUnbond             = 0x58
+	ByteTxSignalUpgrade      = 0x59
+	ByteTxRotateConsensusKey = 0x5A
+	ByteTxProposeOwner       = 0x5B
+	ByteTxAcceptOwner        = 0x5C
+	ByteTxSetOperator        = 0x5D
+	ByteTxSetWithdrawAddress = 0x5E
+	ByteTxRevokeCandidacy    = 0x5F
+	ByteTxPauseCandidacy     = 0x60
+	ByteTxResumeCandidacy    = 0x61
+	ByteTxSetDelegationCap   = 0x62
+	TypeTxDeclareCandidacy   = stakingModuleName + "/declareCandidacy"
+	TypeTxEditCandidacy      = stakingModuleName + "/editCandidacy"
+	TypeTxDelegate           = stakingModuleName + "/delegate"
+	TypeTxUnbond             = stakingModuleName + "/unbond"
+	TypeTxSignalUpgrade      = stakingModuleName + "/signalUpgrade"
+	TypeTxRotateConsensusKey = stakingModuleName + "/rotateConsensusKey"
+	TypeTxProposeOwner       = stakingModuleName + "/proposeOwner"
+	TypeTxAcceptOwner        = stakingModuleName + "/acceptOwner"
+	TypeTxSetOperator        = stakingModuleName + "/setOperator"
+	TypeTxSetWithdrawAddress = stakingModuleName + "/setWithdrawAddress"
+	TypeTxRevokeCandidacy    = stakingModuleName + "/revokeCandidacy"
+	TypeTxPauseCandidacy     = stakingModuleName + "/pauseCandidacy"
+	TypeTxResumeCandidacy    = stakingModuleName + "/resumeCandidacy"
+	TypeTxSetDelegationCap   = stakingModuleName + "/setDelegationCap"
 )
 
 func init() {
@@ -30,10 +50,24 @@ func init() {
 	sdk.TxMapper.RegisterImplementation(TxEditCandidacy{}, TypeTxEditCandidacy, ByteTxEditCandidacy)
 	sdk.TxMapper.RegisterImplementation(TxDelegate{}, TypeTxDelegate, ByteTxDelegate)
 	sdk.TxMapper.RegisterImplementation(TxUnbond{}, TypeTxUnbond, ByteTxUnbond)
+	sdk.TxMapper.RegisterImplementation(TxSignalUpgrade{}, TypeTxSignalUpgrade, ByteTxSignalUpgrade)
+	sdk.TxMapper.RegisterImplementation(TxRotateConsensusKey{}, TypeTxRotateConsensusKey, ByteTxRotateConsensusKey)
+	sdk.TxMapper.RegisterImplementation(TxProposeOwner{}, TypeTxProposeOwner, ByteTxProposeOwner)
+	sdk.TxMapper.RegisterImplementation(TxAcceptOwner{}, TypeTxAcceptOwner, ByteTxAcceptOwner)
+	sdk.TxMapper.RegisterImplementation(TxSetOperator{}, TypeTxSetOperator, ByteTxSetOperator)
+	sdk.TxMapper.RegisterImplementation(TxSetWithdrawAddress{}, TypeTxSetWithdrawAddress, ByteTxSetWithdrawAddress)
+	sdk.TxMapper.RegisterImplementation(TxRevokeCandidacy{}, TypeTxRevokeCandidacy, ByteTxRevokeCandidacy)
+	sdk.TxMapper.RegisterImplementation(TxPauseCandidacy{}, TypeTxPauseCandidacy, ByteTxPauseCandidacy)
+	sdk.TxMapper.RegisterImplementation(TxResumeCandidacy{}, TypeTxResumeCandidacy, ByteTxResumeCandidacy)
+	sdk.TxMapper.RegisterImplementation(TxSetDelegationCap{}, TypeTxSetDelegationCap, ByteTxSetDelegationCap)
 }
 
 //Verify interface at compile time
 var _, _, _, _ sdk.TxInner = &TxDeclareCandidacy{}, &TxEditCandidacy{}, &TxDelegate{}, &TxUnbond{}
+var _, _ sdk.TxInner = &TxSignalUpgrade{}, &TxRotateConsensusKey{}
+var _, _, _ sdk.TxInner = &TxProposeOwner{}, &TxAcceptOwner{}, &TxSetOperator{}
+var _, _ sdk.TxInner = &TxSetWithdrawAddress{}, &TxRevokeCandidacy{}
+var _, _, _ sdk.TxInner = &TxPauseCandidacy{}, &TxResumeCandidacy{}, &TxSetDelegationCap{}
 
 // BondUpdate - struct for bonding or unbonding transactions
 type BondUpdate struct {
@@ -149,3 +183,261 @@ func (tx TxUnbond) ValidateBasic() error {
 	}
 	return nil
 }
+
+// TxSignalUpgrade - struct for a candidate signalling it is ready to run
+// the software of a named upgrade
+type TxSignalUpgrade struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+	Name   string        `json:"name"`
+}
+
+// NewTxSignalUpgrade - new TxSignalUpgrade
+func NewTxSignalUpgrade(pubKey crypto.PubKey, name string) sdk.Tx {
+	return TxSignalUpgrade{
+		PubKey: pubKey,
+		Name:   name,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxSignalUpgrade) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate and upgrade name
+func (tx TxSignalUpgrade) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	if len(tx.Name) == 0 {
+		return errEmptyUpgradeName
+	}
+	return nil
+}
+
+// TxRotateConsensusKey - struct for changing the validator pubkey of a
+// candidate, its delegator bonds follow to the new pubkey
+type TxRotateConsensusKey struct {
+	PubKey    crypto.PubKey `json:"pub_key"`
+	NewPubKey crypto.PubKey `json:"new_pub_key"`
+}
+
+// NewTxRotateConsensusKey - new TxRotateConsensusKey
+func NewTxRotateConsensusKey(pubKey, newPubKey crypto.PubKey) sdk.Tx {
+	return TxRotateConsensusKey{
+		PubKey:    pubKey,
+		NewPubKey: newPubKey,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxRotateConsensusKey) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty and different pubkeys
+func (tx TxRotateConsensusKey) ValidateBasic() error {
+	if tx.PubKey.Empty() || tx.NewPubKey.Empty() {
+		return errCandidateEmpty
+	}
+	if tx.PubKey.Equals(tx.NewPubKey) {
+		return errSamePubKey
+	}
+	return nil
+}
+
+// TxProposeOwner - struct for the owner of a candidate to propose a new
+// owner, the ownership is transferred once the new owner accepts it
+type TxProposeOwner struct {
+	PubKey   crypto.PubKey `json:"pub_key"`
+	NewOwner sdk.Actor     `json:"new_owner"`
+}
+
+// NewTxProposeOwner - new TxProposeOwner
+func NewTxProposeOwner(pubKey crypto.PubKey, newOwner sdk.Actor) sdk.Tx {
+	return TxProposeOwner{
+		PubKey:   pubKey,
+		NewOwner: newOwner,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxProposeOwner) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate and new owner
+func (tx TxProposeOwner) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	if tx.NewOwner.Empty() {
+		return errOwnerEmpty
+	}
+	return nil
+}
+
+// TxAcceptOwner - struct for the proposed owner of a candidate to accept
+// the ownership
+type TxAcceptOwner struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+}
+
+// NewTxAcceptOwner - new TxAcceptOwner
+func NewTxAcceptOwner(pubKey crypto.PubKey) sdk.Tx {
+	return TxAcceptOwner{
+		PubKey: pubKey,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxAcceptOwner) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate
+func (tx TxAcceptOwner) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
+
+// TxSetOperator - struct for the owner of a candidate to set the operator
+// which manages the candidate without access to its funds, an empty
+// operator removes it
+type TxSetOperator struct {
+	PubKey   crypto.PubKey `json:"pub_key"`
+	Operator sdk.Actor     `json:"operator"`
+}
+
+// NewTxSetOperator - new TxSetOperator
+func NewTxSetOperator(pubKey crypto.PubKey, operator sdk.Actor) sdk.Tx {
+	return TxSetOperator{
+		PubKey:   pubKey,
+		Operator: operator,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxSetOperator) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate
+func (tx TxSetOperator) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
+
+// TxSetWithdrawAddress - struct for a delegator to send its payouts, like
+// the coins returned by an unbond, to another account. An empty address
+// sends them back to the delegator.
+type TxSetWithdrawAddress struct {
+	Address sdk.Actor `json:"address"`
+}
+
+// NewTxSetWithdrawAddress - new TxSetWithdrawAddress
+func NewTxSetWithdrawAddress(address sdk.Actor) sdk.Tx {
+	return TxSetWithdrawAddress{
+		Address: address,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxSetWithdrawAddress) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - any address is valid, an empty one resets it
+func (tx TxSetWithdrawAddress) ValidateBasic() error {
+	return nil
+}
+
+// TxRevokeCandidacy - struct for the owner to remove a candidate from the
+// validator election, all its bonds are returned to their delegators
+type TxRevokeCandidacy struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+}
+
+// NewTxRevokeCandidacy - new TxRevokeCandidacy
+func NewTxRevokeCandidacy(pubKey crypto.PubKey) sdk.Tx {
+	return TxRevokeCandidacy{
+		PubKey: pubKey,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxRevokeCandidacy) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate
+func (tx TxRevokeCandidacy) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
+
+// TxPauseCandidacy - struct for a candidate to leave the validator set for
+// maintenance, it keeps its shares and delegators
+type TxPauseCandidacy struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+}
+
+// NewTxPauseCandidacy - new TxPauseCandidacy
+func NewTxPauseCandidacy(pubKey crypto.PubKey) sdk.Tx {
+	return TxPauseCandidacy{
+		PubKey: pubKey,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxPauseCandidacy) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate
+func (tx TxPauseCandidacy) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
+
+// TxResumeCandidacy - struct for a paused candidate to rejoin the election
+type TxResumeCandidacy struct {
+	PubKey crypto.PubKey `json:"pub_key"`
+}
+
+// NewTxResumeCandidacy - new TxResumeCandidacy
+func NewTxResumeCandidacy(pubKey crypto.PubKey) sdk.Tx {
+	return TxResumeCandidacy{
+		PubKey: pubKey,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxResumeCandidacy) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate
+func (tx TxResumeCandidacy) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
+
+// TxSetDelegationCap - struct for a candidate to set the ceiling on the
+// shares it accepts, further delegations are rejected once it is reached.
+// A zero ceiling removes it.
+type TxSetDelegationCap struct {
+	PubKey    crypto.PubKey `json:"pub_key"`
+	MaxShares uint64        `json:"max_shares"`
+}
+
+// NewTxSetDelegationCap - new TxSetDelegationCap
+func NewTxSetDelegationCap(pubKey crypto.PubKey, maxShares uint64) sdk.Tx {
+	return TxSetDelegationCap{
+		PubKey:    pubKey,
+		MaxShares: maxShares,
+	}.Wrap()
+}
+
+// Wrap - Wrap a Tx as a Basecoin Tx
+func (tx TxSetDelegationCap) Wrap() sdk.Tx { return sdk.Tx{tx} }
+
+// ValidateBasic - Check for non-empty candidate
+func (tx TxSetDelegationCap) ValidateBasic() error {
+	if tx.PubKey.Empty() {
+		return errCandidateEmpty
+	}
+	return nil
+}
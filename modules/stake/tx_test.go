@@ -95,7 +95,16 @@ func TestSerializeTx(t *testing.T) {
 		{NewTxUnbond(bondAmt, pubKey)},
 		{NewTxDeclareCandidacy(bond, pubKey, Description{})},
 		{NewTxDeclareCandidacy(bond, pubKey, Description{})},
-		// {NewTxRevokeCandidacy(pubKey)},
+		{NewTxSignalUpgrade(pubKey, "v1")},
+		{NewTxRotateConsensusKey(pubKey, newPubKey("0987654321"))},
+		{NewTxProposeOwner(pubKey, validator)},
+		{NewTxAcceptOwner(pubKey)},
+		{NewTxSetOperator(pubKey, validator)},
+		{NewTxSetWithdrawAddress(validator)},
+		{NewTxRevokeCandidacy(pubKey)},
+		{NewTxPauseCandidacy(pubKey)},
+		{NewTxResumeCandidacy(pubKey)},
+		{NewTxSetDelegationCap(pubKey, 100)},
 	}
 
 	for i, tc := range cases {
@@ -2,9 +2,14 @@ package stake
 
 import (
 	"bytes"
+	"fmt"
+	"math"
 	"sort"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/errors"
+	"github.com/cosmos/cosmos-sdk/stack"
 	"github.com/cosmos/cosmos-sdk/state"
 
 	abci "github.com/tendermint/abci/types"
@@ -12,30 +17,212 @@ import (
 	wire "github.com/tendermint/go-wire"
 )
 
-// Params defines the high level settings for staking
+// Params defines the high level settings for staking. The params are
+// stored, new params are appended at the end with a new SchemaVersion.
 type Params struct {
 	HoldAccount sdk.Actor `json:"hold_account"` // PubKey where all bonded coins are held
 
 	MaxVals          uint16 `json:"max_vals"`           // maximum number of validators
 	AllowedBondDenom string `json:"allowed_bond_denom"` // bondable coin denomination
 
+	// software upgrades
+	UpgradeThreshold int64 `json:"upgrade_threshold"` // percent of the voting power which must signal an upgrade
+	UpgradeDelay     int64 `json:"upgrade_delay"`     // blocks between the signalling threshold and the halt height
+
 	// gas costs for txs
 	GasDeclareCandidacy int64 `json:"gas_declare_candidacy"`
 	GasEditCandidacy    int64 `json:"gas_edit_candidacy"`
 	GasDelegate         int64 `json:"gas_delegate"`
 	GasUnbond           int64 `json:"gas_unbond"`
+
+	MaxPauseDuration int64 `json:"max_pause_duration"` // blocks a candidate can stay paused for maintenance
+
+	MinSelfBond   int64 `json:"min_self_bond"`  // minimum bond of the owner of a validator
+	MinDelegation int64 `json:"min_delegation"` // minimum bond of a delegator
+
+	// caps on the delegations to a candidate, zero for no cap
+	MaxCandidatePowerFraction int64 `json:"max_candidate_power_fraction"` // percent of all the bonded shares a candidate can hold
+	MaxDelegatorsPerCandidate int64 `json:"max_delegators_per_candidate"` // number of delegators a candidate can have
+
+	// limits on the change of the voting power of a validator at each
+	// validator set update, zero for no limit, the smallest applies when
+	// both are set
+	MaxPowerChangePerBlock        int64 `json:"max_power_change_per_block"`         // absolute change of the voting power
+	MaxPowerChangePercentPerBlock int64 `json:"max_power_change_percent_per_block"` // percent of the current voting power
+
+	EpochLength int64 `json:"epoch_length"` // blocks between two validator set updates
+
+	// election of the validators
+	ElectionPolicy   string `json:"election_policy"`    // name of the registered election policy
+	ElectionPowerCap int64  `json:"election_power_cap"` // percent of the power of the validators one can hold with the capped policy, zero for no cap
+
+	// gas costs for the other txs
+	GasSignalUpgrade      int64 `json:"gas_signal_upgrade"`
+	GasRotateConsensusKey int64 `json:"gas_rotate_consensus_key"`
+	GasProposeOwner       int64 `json:"gas_propose_owner"`
+	GasAcceptOwner        int64 `json:"gas_accept_owner"`
+	GasSetOperator        int64 `json:"gas_set_operator"`
+	GasSetWithdrawAddress int64 `json:"gas_set_withdraw_address"`
+	GasRevokeCandidacy    int64 `json:"gas_revoke_candidacy"` // plus gas_unbond for each returned bond
+	GasPauseCandidacy     int64 `json:"gas_pause_candidacy"`
+	GasResumeCandidacy    int64 `json:"gas_resume_candidacy"`
+	GasSetDelegationCap   int64 `json:"gas_set_delegation_cap"`
 }
 
 func defaultParams() Params {
 	return Params{
-		HoldAccount:         sdk.NewActor(stakingModuleName, []byte("77777777777777777777777777777777")),
-		MaxVals:             100,
-		AllowedBondDenom:    "fermion",
-		GasDeclareCandidacy: 20,
-		GasEditCandidacy:    20,
-		GasDelegate:         20,
-		GasUnbond:           20,
+		HoldAccount:           sdk.NewActor(stakingModuleName, []byte("77777777777777777777777777777777")),
+		MaxVals:               100,
+		AllowedBondDenom:      "fermion",
+		UpgradeThreshold:      67,
+		UpgradeDelay:          1000,
+		MaxPauseDuration:      10000,
+		EpochLength:           1,
+		ElectionPolicy:        ElectionTopN,
+		GasDeclareCandidacy:   20,
+		GasEditCandidacy:      20,
+		GasDelegate:           20,
+		GasUnbond:             20,
+		GasSignalUpgrade:      20,
+		GasRotateConsensusKey: 20,
+		GasProposeOwner:       20,
+		GasAcceptOwner:        20,
+		GasSetOperator:        20,
+		GasSetWithdrawAddress: 20,
+		GasRevokeCandidacy:    20,
+		GasPauseCandidacy:     20,
+		GasResumeCandidacy:    20,
+		GasSetDelegationCap:   20,
+	}
+}
+
+// set a parameter from its genesis key and string value
+// ValidateParam - check that a parameter change would be accepted on the
+// params, which are left unchanged
+func (p Params) ValidateParam(key, value string) error {
+	return p.set(key, value)
+}
+
+func (p *Params) set(key, value string) error {
+	switch key {
+	case "allowed_bond_denom":
+		if len(value) == 0 {
+			return fmt.Errorf("bond denomination cannot be empty")
+		}
+		p.AllowedBondDenom = value
+	case "election_policy":
+		if _, ok := electionPolicies[value]; !ok {
+			return ErrUnknownElection(value)
+		}
+		p.ElectionPolicy = value
+	case "max_vals",
+		"upgrade_threshold",
+		"upgrade_delay",
+		"max_pause_duration",
+		"min_self_bond",
+		"min_delegation",
+		"max_candidate_power_fraction",
+		"max_delegators_per_candidate",
+		"max_power_change_per_block",
+		"max_power_change_percent_per_block",
+		"epoch_length",
+		"election_power_cap",
+		"gas_declare_candidacy",
+		"gas_edit_candidacy",
+		"gas_bond",
+		"gas_unbond",
+		"gas_signal_upgrade",
+		"gas_rotate_consensus_key",
+		"gas_propose_owner",
+		"gas_accept_owner",
+		"gas_set_operator",
+		"gas_set_withdraw_address",
+		"gas_revoke_candidacy",
+		"gas_pause_candidacy",
+		"gas_resume_candidacy",
+		"gas_set_delegation_cap":
+
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("input must be integer, Error: %v", err.Error())
+		}
+		if i < 0 {
+			return fmt.Errorf("input must be a non-negative integer, got %v", i)
+		}
+
+		switch key {
+		case "max_vals":
+			if i > math.MaxUint16 {
+				return fmt.Errorf("max_vals must be at most %v, got %v", math.MaxUint16, i)
+			}
+			p.MaxVals = uint16(i)
+		case "upgrade_threshold":
+			if i > 100 {
+				return fmt.Errorf("upgrade_threshold is a percentage, got %v", i)
+			}
+			p.UpgradeThreshold = int64(i)
+		case "upgrade_delay":
+			p.UpgradeDelay = int64(i)
+		case "max_pause_duration":
+			p.MaxPauseDuration = int64(i)
+		case "min_self_bond":
+			p.MinSelfBond = int64(i)
+		case "min_delegation":
+			p.MinDelegation = int64(i)
+		case "max_candidate_power_fraction":
+			if i > 100 {
+				return fmt.Errorf("max_candidate_power_fraction is a percentage, got %v", i)
+			}
+			p.MaxCandidatePowerFraction = int64(i)
+		case "max_delegators_per_candidate":
+			p.MaxDelegatorsPerCandidate = int64(i)
+		case "max_power_change_per_block":
+			p.MaxPowerChangePerBlock = int64(i)
+		case "max_power_change_percent_per_block":
+			p.MaxPowerChangePercentPerBlock = int64(i)
+		case "epoch_length":
+			if i == 0 {
+				return fmt.Errorf("epoch_length must be positive")
+			}
+			p.EpochLength = int64(i)
+		case "election_power_cap":
+			if i > 100 {
+				return fmt.Errorf("election_power_cap is a percentage, got %v", i)
+			}
+			p.ElectionPowerCap = int64(i)
+		case "gas_declare_candidacy":
+			p.GasDeclareCandidacy = int64(i)
+		case "gas_edit_candidacy":
+			p.GasEditCandidacy = int64(i)
+		case "gas_bond":
+			p.GasDelegate = int64(i)
+		case "gas_unbond":
+			p.GasUnbond = int64(i)
+		case "gas_signal_upgrade":
+			p.GasSignalUpgrade = int64(i)
+		case "gas_rotate_consensus_key":
+			p.GasRotateConsensusKey = int64(i)
+		case "gas_propose_owner":
+			p.GasProposeOwner = int64(i)
+		case "gas_accept_owner":
+			p.GasAcceptOwner = int64(i)
+		case "gas_set_operator":
+			p.GasSetOperator = int64(i)
+		case "gas_set_withdraw_address":
+			p.GasSetWithdrawAddress = int64(i)
+		case "gas_revoke_candidacy":
+			p.GasRevokeCandidacy = int64(i)
+		case "gas_pause_candidacy":
+			p.GasPauseCandidacy = int64(i)
+		case "gas_resume_candidacy":
+			p.GasResumeCandidacy = int64(i)
+		case "gas_set_delegation_cap":
+			p.GasSetDelegationCap = int64(i)
+		}
+	default:
+		return errors.ErrUnknownKey(key)
 	}
+	return nil
 }
 
 //_________________________________________________________________________
@@ -48,12 +235,27 @@ func defaultParams() Params {
 // exchange rate. Voting power can be calculated as total bonds multiplied by
 // exchange rate.
 // NOTE if the Owner.Empty() == true then this is a candidate who has revoked candidacy
+// NOTE new fields are appended at the end with a new SchemaVersion
 type Candidate struct {
 	PubKey      crypto.PubKey `json:"pub_key"`      // Pubkey of candidate
 	Owner       sdk.Actor     `json:"owner"`        // Sender of BondTx - UnbondTx returns here
 	Shares      uint64        `json:"shares"`       // Total number of delegated shares to this candidate, equivalent to coins held in bond account
 	VotingPower uint64        `json:"voting_power"` // Voting power if pubKey is a considered a validator
 	Description Description   `json:"description"`  // Description terms for the candidate
+
+	PendingOwner *sdk.Actor    `json:"pending_owner"` // Owner proposed by the Owner, until it accepts the ownership
+	OwnerChanges []OwnerChange `json:"owner_changes"` // Past transfers of the ownership
+	Operator     *sdk.Actor    `json:"operator"`      // Actor allowed to manage the candidate with the Owner, it can not move funds
+	PausedHeight int64         `json:"paused_height"` // Height the candidate was paused at for maintenance, zero if it is not paused
+	MaxShares    uint64        `json:"max_shares"`    // Ceiling on the shares the candidate accepts, zero for no ceiling
+	TargetPower  uint64        `json:"target_power"`  // Voting power the validator moves to, limited by the maximum power change per block
+}
+
+// OwnerChange - record of a transfer of the ownership of a candidate
+type OwnerChange struct {
+	Height   int64     `json:"height"`
+	OldOwner sdk.Actor `json:"old_owner"`
+	NewOwner sdk.Actor `json:"new_owner"`
 }
 
 // Description - description fields for a candidate
@@ -74,6 +276,30 @@ func NewCandidate(pubKey crypto.PubKey, owner sdk.Actor) *Candidate {
 	}
 }
 
+// canOperate - the owner and the operator can manage the candidate, only the
+// owner can move its funds or its ownership
+func (c *Candidate) canOperate(actor sdk.Actor) bool {
+	if c.Owner.Equals(actor) {
+		return true
+	}
+	return c.Operator != nil && c.Operator.Equals(actor)
+}
+
+// paused - a paused candidate keeps its shares but is not elected
+func (c *Candidate) paused() bool {
+	return c.PausedHeight > 0
+}
+
+// hasMinSelfBond - the owner must keep the minimum self-bond for the
+// candidate to be elected
+func (c *Candidate) hasMinSelfBond(store state.SimpleDB, params Params) bool {
+	if params.MinSelfBond == 0 {
+		return true
+	}
+	bond := loadDelegatorBond(store, c.Owner, c.PubKey)
+	return bond != nil && bond.Shares >= uint64(params.MinSelfBond)
+}
+
 // Validator returns a copy of the Candidate as a Validator.
 // Should only be called when the Candidate qualifies as a validator.
 func (c *Candidate) validator() Validator {
@@ -127,44 +353,77 @@ func (cs Candidates) Sort() {
 // update the voting power and save
 func (cs Candidates) updateVotingPower(store state.SimpleDB) Candidates {
 
-	// update voting power
+	// the target power is the shares, the paused candidates and the
+	// candidates without the minimum self-bond leave their slot to the next
+	// candidates
+	params := loadParams(store)
+	power := make(map[*Candidate]uint64, len(cs))
 	for _, c := range cs {
-		if c.VotingPower != c.Shares {
+		power[c] = c.VotingPower
+		if c.paused() || !c.hasMinSelfBond(store, params) {
+			c.VotingPower = 0
+		} else if c.VotingPower != c.Shares {
 			c.VotingPower = c.Shares
 		}
 	}
 	cs.Sort()
-	for i, c := range cs {
-		// truncate the power
-		if i >= int(loadParams(store).MaxVals) {
-			c.VotingPower = 0
-		}
+
+	// elect the validators, with the policy of the params
+	electionPolicy(params).Elect(cs, params, loadElectionSeed(store))
+	for _, c := range cs {
+
+		// move the power toward the target
+		c.TargetPower = c.VotingPower
+		c.VotingPower = params.movePower(power[c], c.TargetPower)
 		saveCandidate(store, c)
 	}
 	return cs
 }
 
-// Validators - get the most recent updated validator set from the
-// Candidates. These bonds are already sorted by VotingPower from
-// the UpdateVotingPower function which is the only function which
-// is to modify the VotingPower
-func (cs Candidates) Validators() Validators {
-
-	//test if empty
-	if len(cs) == 1 {
-		if cs[0].VotingPower == 0 {
-			return nil
+// movePower - the voting power of a validator after one block moving from
+// power toward target within the limits on the change per block. Leaving
+// the validator set is never limited, and the relative limit only applies
+// to the validators which already had power: a new validator enters the set
+// with its target power unless the absolute limit is set.
+func (p Params) movePower(power, target uint64) uint64 {
+	var limit uint64 // zero for no limit
+	if p.MaxPowerChangePerBlock > 0 {
+		limit = uint64(p.MaxPowerChangePerBlock)
+	}
+	if p.MaxPowerChangePercentPerBlock > 0 && power > 0 {
+		relative := power * uint64(p.MaxPowerChangePercentPerBlock) / 100
+		if relative == 0 {
+			relative = 1 // a small validator must be able to grow
+		}
+		if limit == 0 || relative < limit {
+			limit = relative
 		}
 	}
 
-	validators := make(Validators, len(cs))
-	for i, c := range cs {
-		if c.VotingPower == 0 { //exit as soon as the first Voting power set to zero is found
-			return validators[:i]
-		}
-		validators[i] = c.validator()
+	switch {
+	case limit == 0, target == 0:
+		return target
+	case target > power && target-power > limit:
+		return power + limit
+	case target < power && power-target > limit:
+		return power - limit
 	}
+	return target
+}
 
+// Validators - get the most recent updated validator set from the
+// Candidates, the candidates with a non-zero VotingPower as set by the
+// UpdateVotingPower function which is the only function which is to modify
+// the VotingPower. Paused candidates are skipped from the update following
+// their pause. The candidates are not necessarily sorted by power, as
+// loaded from the store they are in the order of their declaration.
+func (cs Candidates) Validators() (validators Validators) {
+	for _, c := range cs {
+		if c.VotingPower == 0 {
+			continue
+		}
+		validators = append(validators, c.validator())
+	}
 	return validators
 }
 
@@ -239,12 +498,51 @@ func (vs Validators) validatorsChanged(vs2 Validators) (changed []*abci.Validato
 // UpdateValidatorSet - Updates the voting power for the candidate set and
 // returns the subset of validators which have changed for Tendermint
 func UpdateValidatorSet(store state.SimpleDB) (change []*abci.Validator, err error) {
+	change, _, _, err = updateValidatorSet(store)
+	return
+}
+
+// UpdateValidatorSet - UpdateValidatorSet calling the hooks of the handler
+// on the validators entering and leaving the set. It needs the global store.
+func (h Handler) UpdateValidatorSet(ctx sdk.Context, store state.SimpleDB) (change []*abci.Validator, err error) {
+	change, v1, v2, err := updateValidatorSet(stack.PrefixedStore(stakingModuleName, store))
+	if err != nil {
+		return nil, err
+	}
+	h.hooks.validatorSetChanged(ctx, store, v1, v2)
+	return change, nil
+}
+
+// updateValidatorSet - update the validator set, and return the validators
+// before and after the update
+func updateValidatorSet(store state.SimpleDB) (change []*abci.Validator, v1, v2 Validators, err error) {
 
 	// get the validators before update
 	candidates := loadCandidates(store)
 
-	v1 := candidates.Validators()
-	v2 := candidates.updateVotingPower(store).Validators()
+	v1 = candidates.Validators()
+	v2 = candidates.updateVotingPower(store).Validators()
+
+	// validators which rotated their key during the block were in the
+	// set under their previous key, which is removed by the change
+	rotations := loadKeyRotations(store)
+	for i := range v1 {
+		for _, rotation := range rotations {
+			if v1[i].PubKey.Equals(rotation.NewPubKey) {
+				v1[i].PubKey = rotation.OldPubKey
+			}
+		}
+	}
+	if len(rotations) > 0 {
+		removeKeyRotations(store)
+	}
+
+	// the removed candidates are no longer loaded
+	removed := loadRemovedValidators(store)
+	if len(removed) > 0 {
+		v1 = append(v1, removed...)
+		removeRemovedValidators(store)
+	}
 
 	change = v1.validatorsChanged(v2)
 	return
@@ -129,6 +129,55 @@ func TestUpdateVotingPower(t *testing.T) {
 	assert.Equal(uint64(0), candidates[4].VotingPower, "%v", candidates[4])
 }
 
+func TestRateLimitedVotingPower(t *testing.T) {
+	assert := assert.New(t)
+	store := state.NewMemKVStore()
+
+	actors := newActors(2)
+	candidates := candidatesFromActors(actors, []int{400, 200})
+	params := loadParams(store)
+	params.MaxPowerChangePerBlock = 50
+	saveParams(store, params)
+
+	// the power moves toward the target by at most 50 per block
+	candidates[0].Shares = 520
+	candidates[1].Shares = 100
+	candidates.updateVotingPower(store)
+	assert.Equal(uint64(450), candidates[0].VotingPower)
+	assert.Equal(uint64(520), candidates[0].TargetPower)
+	assert.Equal(uint64(150), candidates[1].VotingPower)
+	assert.Equal(uint64(100), candidates[1].TargetPower)
+	candidates.updateVotingPower(store)
+	candidates.updateVotingPower(store)
+	assert.Equal(uint64(520), candidates[0].VotingPower)
+	assert.Equal(uint64(100), candidates[1].VotingPower)
+
+	// the relative limit is 10% of the power, the smallest limit applies
+	params.MaxPowerChangePercentPerBlock = 10
+	saveParams(store, params)
+	candidates[0].Shares = 1000
+	candidates[1].Shares = 1000
+	candidates.updateVotingPower(store)
+	assert.Equal(uint64(570), candidates[0].VotingPower)
+	assert.Equal(uint64(110), candidates[1].VotingPower)
+
+	// a new validator is only limited by the absolute limit, a small
+	// validator grows, leaving the set is immediate
+	assert.Equal(uint64(50), params.movePower(0, 1000))
+	params.MaxPowerChangePerBlock = 0
+	saveParams(store, params)
+	assert.Equal(uint64(1000), params.movePower(0, 1000))
+	assert.Equal(uint64(2), params.movePower(1, 1000))
+	assert.Equal(uint64(0), params.movePower(570, 0))
+
+	// a new candidate enters the set with its shares
+	candidates = append(candidates, &Candidate{PubKey: pks[2], Owner: newActors(3)[2], Shares: 300})
+	candidates.updateVotingPower(store)
+	assert.Equal(uint64(627), candidates[0].VotingPower)
+	assert.Equal(uint64(121), candidates[1].VotingPower)
+	assert.Equal(uint64(300), candidates[2].VotingPower)
+}
+
 func TestGetValidators(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 
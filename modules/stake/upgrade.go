@@ -0,0 +1,118 @@
+package stake
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// UpgradePlan - an upgrade signalled by enough of the voting power, the
+// nodes halt at Height until they run the software of the upgrade
+type UpgradePlan struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+// Migration - a state migration of the stake store, run by the software of
+// an upgrade at the halt height of its plan
+type Migration func(store state.SimpleDB) error
+
+var migrations = make(map[string]Migration)
+
+// RegisterMigration - register the stake store migration of a named upgrade.
+// A binary must register the upgrades it is able to perform, any other
+// scheduled upgrade halts the node at its height.
+func RegisterMigration(name string, migration Migration) {
+	if _, ok := migrations[name]; ok {
+		panic("stake migration already registered for upgrade " + name)
+	}
+	migrations[name] = migration
+}
+
+// ApplyUpgrade - run the migration of the upgrade planned at this height.
+// It is run at the beginning of the block at the halt height, so the txs of
+// the block run on the migrated store. An error recognised by
+// IsUpgradeNeededErr is returned if the planned upgrade is not registered
+// in this binary.
+func ApplyUpgrade(ctx sdk.Context, store state.SimpleDB) error {
+	height := ctx.BlockHeight()
+	plan := loadUpgradePlan(store)
+	if plan == nil || height < plan.Height {
+		return nil
+	}
+	migration, ok := migrations[plan.Name]
+	if !ok {
+		return ErrUpgradeNeeded(*plan)
+	}
+	err := migration(store)
+	if err != nil {
+		return err
+	}
+
+	// the upgrade is complete, clear the plan and the signals
+	removeUpgradePlan(store)
+	for _, candidate := range loadCandidates(store) {
+		store.Remove(GetUpgradeSignalKey(candidate.PubKey))
+	}
+	ctx.Info("Upgrade applied", "name", plan.Name, "height", height)
+	return nil
+}
+
+// ProcessUpgrades - schedule an upgrade once the validators signalling it
+// hold enough voting power, run by the tick
+func ProcessUpgrades(ctx sdk.Context, store state.SimpleDB) error {
+	if loadUpgradePlan(store) != nil {
+		return nil
+	}
+
+	height := ctx.BlockHeight()
+	params := loadParams(store)
+	name, power, total := tallyUpgradeSignals(store)
+	if total == 0 || power*100 < uint64(params.UpgradeThreshold)*total {
+		return nil
+	}
+	scheduled := UpgradePlan{
+		Name:   name,
+		Height: height + params.UpgradeDelay,
+	}
+	saveUpgradePlan(store, scheduled)
+	ctx.Info("Upgrade scheduled", "name", scheduled.Name, "height", scheduled.Height)
+	return nil
+}
+
+// CheckUpgrade - whether this binary can process the block at a height: an
+// error recognised by IsUpgradeNeededErr is returned from the halt height of
+// a planned upgrade which is not registered in this binary. The node stops
+// once the block before the halt height is committed, and the binary of the
+// upgrade is restarted on that state: it processes the block at the halt
+// height, which begins with the migration.
+func CheckUpgrade(store state.SimpleDB, height int64) error {
+	plan := loadUpgradePlan(store)
+	if plan == nil || height < plan.Height {
+		return nil
+	}
+	if _, ok := migrations[plan.Name]; !ok {
+		return ErrUpgradeNeeded(*plan)
+	}
+	return nil
+}
+
+// tallyUpgradeSignals - get the upgrade signalled by the most voting power,
+// the voting power behind it and the total voting power of the validators
+func tallyUpgradeSignals(store state.SimpleDB) (name string, power, total uint64) {
+	powers := make(map[string]uint64)
+	for _, candidate := range loadCandidates(store) {
+		total += candidate.VotingPower
+		signal := loadUpgradeSignal(store, candidate.PubKey)
+		if signal == "" {
+			continue
+		}
+		powers[signal] += candidate.VotingPower
+
+		// ties are broken by name to keep the tally deterministic
+		p := powers[signal]
+		if p > power || (p == power && signal < name) {
+			name, power = signal, p
+		}
+	}
+	return
+}
@@ -0,0 +1,116 @@
+package stake
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tmlibs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/stack"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+func TestSignalUpgrade(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+
+	deliverer := newDeliver(senders[0], accStore)
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	checker := check{
+		store:  deliverer.store,
+		sender: senders[0],
+	}
+
+	// only the owner of an existing candidate can signal
+	assert.NoError(checker.signalUpgrade(TxSignalUpgrade{pk1, "v1"}))
+	assert.Error(checker.signalUpgrade(TxSignalUpgrade{pk2, "v1"}))
+	checker.sender = senders[1]
+	assert.Error(checker.signalUpgrade(TxSignalUpgrade{pk1, "v1"}))
+
+	// the latest signal replaces the previous one
+	require.NoError(deliverer.signalUpgrade(TxSignalUpgrade{pk1, "v1"}))
+	require.NoError(deliverer.signalUpgrade(TxSignalUpgrade{pk1, "v2"}))
+	assert.Equal("v2", loadUpgradeSignal(deliverer.store, pk1))
+
+	// no signals are accepted once an upgrade is scheduled
+	saveUpgradePlan(deliverer.store, UpgradePlan{"v2", 100})
+	checker.sender = senders[0]
+	assert.Error(checker.signalUpgrade(TxSignalUpgrade{pk1, "v3"}))
+}
+
+func TestProcessUpgrades(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(3, 1000)
+	amounts := []int64{10, 30, 60}
+
+	// three validators with 10%, 30% and 60% of the voting power
+	deliverer := newDeliver(senders[0], accStore)
+	store := deliverer.store
+	for i, pk := range pks[:3] {
+		deliverer.sender = senders[i]
+		require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(amounts[i], pk)))
+	}
+	_, err := UpdateValidatorSet(store)
+	require.NoError(err)
+	params := loadParams(store)
+	newCtx := func(height int64) sdk.Context {
+		return stack.NewContext("testChain", height, log.NewNopLogger())
+	}
+
+	// 40% of the power is below the threshold
+	saveUpgradeSignal(store, pks[0], "v1")
+	saveUpgradeSignal(store, pks[1], "v1")
+	saveUpgradeSignal(store, pks[2], "v2")
+	require.NoError(ProcessUpgrades(newCtx(10), store))
+	assert.Nil(loadUpgradePlan(store))
+
+	// 70% is enough to schedule the upgrade
+	saveUpgradeSignal(store, pks[2], "v1")
+	require.NoError(ProcessUpgrades(newCtx(11), store))
+	plan := loadUpgradePlan(store)
+	require.NotNil(plan)
+	haltHeight := 11 + params.UpgradeDelay
+	assert.Equal(UpgradePlan{"v1", haltHeight}, *plan)
+
+	// the node stops once the block before the halt height is committed,
+	// and cannot process the block at the halt height without the migration
+	require.NoError(ApplyUpgrade(newCtx(haltHeight-1), store))
+	require.NoError(ProcessUpgrades(newCtx(haltHeight-1), store))
+	require.NoError(CheckUpgrade(store, haltHeight-1))
+	err = CheckUpgrade(store, haltHeight)
+	assert.True(IsUpgradeNeededErr(err), "%v", err)
+	err = ApplyUpgrade(newCtx(haltHeight), store)
+	assert.True(IsUpgradeNeededErr(err), "%v", err)
+
+	// the binary of the upgrade is restarted on the state of the block
+	// before the halt height, and runs the registered migration once
+	var migrated int
+	RegisterMigration("v1", func(store state.SimpleDB) error {
+		migrated++
+		return nil
+	})
+	defer delete(migrations, "v1")
+	require.NoError(CheckUpgrade(store, haltHeight))
+	require.NoError(ApplyUpgrade(newCtx(haltHeight), store))
+	require.NoError(ProcessUpgrades(newCtx(haltHeight), store))
+	require.NoError(ApplyUpgrade(newCtx(haltHeight+1), store))
+	assert.Equal(1, migrated)
+	assert.Nil(loadUpgradePlan(store))
+	for _, pk := range pks[:3] {
+		assert.Equal("", loadUpgradeSignal(store, pk))
+	}
+
+	// a failing migration is reported
+	saveUpgradePlan(store, UpgradePlan{"v2", haltHeight + 2})
+	RegisterMigration("v2", func(store state.SimpleDB) error {
+		return fmt.Errorf("migration failed")
+	})
+	defer delete(migrations, "v2")
+	err = ApplyUpgrade(newCtx(haltHeight+2), store)
+	assert.Error(err)
+	assert.False(IsUpgradeNeededErr(err))
+}
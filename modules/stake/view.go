@@ -0,0 +1,111 @@
+package stake
+
+import (
+	crypto "github.com/tendermint/go-crypto"
+
+	sdk "github.com/cosmos/cosmos-sdk"
+	"github.com/cosmos/cosmos-sdk/state"
+)
+
+// View - read-only access to the state of the stake module, for the other
+// modules and the client. The objects returned are copies of the state,
+// changing them does not change the state.
+type View struct {
+	store state.SimpleDB
+}
+
+// NewView - the view of the stake state in the store of the stake module,
+// stack.PrefixedStore(stake.Name(), store) of the global store
+func NewView(store state.SimpleDB) View {
+	return View{store}
+}
+
+// Params - the current params
+func (v View) Params() Params {
+	return loadParams(v.store)
+}
+
+// Epoch - the current epoch
+func (v View) Epoch() Epoch {
+	return loadEpoch(v.store)
+}
+
+// Candidate - the candidate of a pubkey, nil if there is none
+func (v View) Candidate(pubKey crypto.PubKey) *Candidate {
+	return loadCandidate(v.store, pubKey)
+}
+
+// Candidates - all the candidates, in the order of their declaration
+func (v View) Candidates() Candidates {
+	return loadCandidates(v.store)
+}
+
+// IterateCandidates - call fn on each candidate, in the order of their
+// declaration, until it returns true
+func (v View) IterateCandidates(fn func(candidate *Candidate) (stop bool)) {
+	for _, pk := range loadCandidatesPubKeys(v.store) {
+		if fn(loadCandidate(v.store, pk)) {
+			return
+		}
+	}
+}
+
+// Validators - the validators of the last validator set update, the
+// candidates with voting power
+func (v View) Validators() Validators {
+	return loadCandidates(v.store).Validators()
+}
+
+// IterateValidators - call fn on each validator until it returns true
+func (v View) IterateValidators(fn func(validator Validator) (stop bool)) {
+	v.IterateCandidates(func(candidate *Candidate) bool {
+		if candidate.VotingPower == 0 {
+			return false
+		}
+		return fn(candidate.validator())
+	})
+}
+
+// DelegatorBond - the bond of a delegator to a candidate, nil if there is
+// none
+func (v View) DelegatorBond(delegator sdk.Actor, pubKey crypto.PubKey) *DelegatorBond {
+	return loadDelegatorBond(v.store, delegator, pubKey)
+}
+
+// DelegatorBonds - all the bonds of a delegator
+func (v View) DelegatorBonds(delegator sdk.Actor) (bonds []DelegatorBond) {
+	for _, pk := range loadDelegatorCandidates(v.store, delegator) {
+		bonds = append(bonds, *loadDelegatorBond(v.store, delegator, pk))
+	}
+	return
+}
+
+// DelegatorCandidates - the pubkeys of the candidates a delegator is bonded
+// to
+func (v View) DelegatorCandidates(delegator sdk.Actor) []crypto.PubKey {
+	return loadDelegatorCandidates(v.store, delegator)
+}
+
+// CandidateDelegators - the delegators bonded to a candidate, its owner
+// included
+func (v View) CandidateDelegators(pubKey crypto.PubKey) []sdk.Actor {
+	return loadCandidateDelegators(v.store, pubKey)
+}
+
+// BondedTokens - the tokens bonded by an actor to all the candidates, the
+// delegations and unbonds queued during the epoch are not included
+func (v View) BondedTokens(actor sdk.Actor) (tokens uint64) {
+	for _, bond := range v.DelegatorBonds(actor) {
+		tokens += bond.Shares // currently each share is worth one coin
+	}
+	return
+}
+
+// TotalBonded - the tokens bonded to all the candidates
+func (v View) TotalBonded() (tokens uint64) {
+	v.IterateCandidates(func(candidate *Candidate) bool {
+		tokens += candidate.Shares // currently each share is worth one coin
+		return false
+	})
+	return
+}
@@ -0,0 +1,58 @@
+package stake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestView(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	senders, accStore := initAccounts(2, 1000)
+	owner, delegator := senders[0], senders[1]
+	deliverer := newDeliver(owner, accStore)
+	view := NewView(deliverer.store)
+
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(10, pk1)))
+	require.NoError(deliverer.declareCandidacy(newTxDeclareCandidacy(20, pk2)))
+	deliverer.sender = delegator
+	require.NoError(deliverer.delegate(newTxDelegate(5, pk1)))
+	_, err := UpdateValidatorSet(deliverer.store)
+	require.NoError(err)
+
+	assert.Equal(uint64(30), view.BondedTokens(owner))
+	assert.Equal(uint64(5), view.BondedTokens(delegator))
+	assert.Equal(uint64(35), view.TotalBonded())
+	assert.Equal(uint64(15), view.Candidate(pk1).Shares)
+	assert.Nil(view.Candidate(pk3))
+	assert.Equal(uint64(5), view.DelegatorBond(delegator, pk1).Shares)
+	assert.Equal(2, len(view.DelegatorBonds(owner)))
+	assert.Equal(2, len(view.CandidateDelegators(pk1)))
+	assert.Equal(loadParams(deliverer.store), view.Params())
+
+	// the iterations stop when asked
+	var n int
+	view.IterateCandidates(func(candidate *Candidate) bool {
+		n++
+		return true
+	})
+	assert.Equal(1, n)
+
+	// the validators are the candidates with voting power
+	params := view.Params()
+	params.MaxVals = 1
+	saveParams(deliverer.store, params)
+	_, err = UpdateValidatorSet(deliverer.store)
+	require.NoError(err)
+	assert.Equal(2, len(view.Candidates()))
+	validators := view.Validators()
+	require.Equal(1, len(validators))
+	assert.True(pk2.Equals(validators[0].PubKey))
+	var powers []uint64
+	view.IterateValidators(func(validator Validator) bool {
+		powers = append(powers, validator.VotingPower)
+		return false
+	})
+	assert.Equal([]uint64{20}, powers)
+}